@@ -0,0 +1,370 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/serviceconfig"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/config/internal/endpoints"
+)
+
+// clientBalancerName is the name of the load balancing policy implementing
+// ConnectionPoolConfig and LoadBalancingConfig.
+const clientBalancerName = "otelcol_client"
+
+func init() {
+	balancer.Register(clientBalancerBuilder{})
+}
+
+type clientBalancerConfig struct {
+	serviceconfig.LoadBalancingConfig `json:"-"`
+
+	PoolSize             int           `json:"poolSize"`
+	MaxConcurrentStreams int           `json:"maxConcurrentStreams"`
+	Policy               string        `json:"policy"`
+	EjectionFailures     int           `json:"ejectionFailures"`
+	EjectionDuration     time.Duration `json:"ejectionDuration"`
+}
+
+// clientBalancerServiceConfig returns the gRPC service config selecting the client
+// load balancing policy, configured by the connection pool and load balancing
+// configurations, any of which may be nil.
+func clientBalancerServiceConfig(pool *ConnectionPoolConfig, lb *LoadBalancingConfig) string {
+	cfg := clientBalancerConfig{
+		PoolSize: 1,
+		Policy:   endpoints.PolicyRoundRobin,
+	}
+	if pool != nil {
+		cfg.PoolSize = max(pool.Size, 1)
+		cfg.MaxConcurrentStreams = pool.MaxConcurrentStreams
+	}
+	if lb != nil {
+		if lb.Policy != "" {
+			cfg.Policy = lb.Policy
+		}
+		cfg.EjectionFailures, cfg.EjectionDuration = lb.EjectionOrDefault()
+	}
+	js, _ := json.Marshal(cfg)
+	return fmt.Sprintf(`{"loadBalancingConfig":[{"%s":%s}]}`, clientBalancerName, js)
+}
+
+type clientBalancerBuilder struct{}
+
+func (clientBalancerBuilder) Build(cc balancer.ClientConn, _ balancer.BuildOptions) balancer.Balancer {
+	return &clientBalancer{
+		cc:    cc,
+		pools: resolver.NewAddressMap(),
+	}
+}
+
+func (clientBalancerBuilder) Name() string {
+	return clientBalancerName
+}
+
+func (clientBalancerBuilder) ParseConfig(js json.RawMessage) (serviceconfig.LoadBalancingConfig, error) {
+	cfg := &clientBalancerConfig{}
+	if err := json.Unmarshal(js, cfg); err != nil {
+		return nil, fmt.Errorf("invalid %s config: %w", clientBalancerName, err)
+	}
+	if cfg.PoolSize < 1 || cfg.MaxConcurrentStreams < 0 || cfg.EjectionFailures < 0 {
+		return nil, fmt.Errorf("invalid %s config: %s", clientBalancerName, js)
+	}
+	return cfg, nil
+}
+
+// clientBalancer opens a pool of connections to each resolved address, and picks a ready
+// connection for each RPC according to the policy, skipping the ones at their limit of
+// concurrent RPCs and the ones to ejected addresses.
+type clientBalancer struct {
+	cc balancer.ClientConn
+
+	mu  sync.Mutex
+	cfg *clientBalancerConfig
+	// pools maps each address to its pool of connections.
+	pools *resolver.AddressMap
+	// ready are the ready connections of all the pools.
+	ready []*pooledConn
+	next  int
+	// saturated is set when an RPC could not be picked because all the ready
+	// connections reached their limit, so a new picker must be sent to retry it
+	// once an RPC ends.
+	saturated   bool
+	resolverErr error
+	state       connectivity.State
+}
+
+// addressPool is the pool of connections to an address.
+type addressPool struct {
+	conns  []*pooledConn
+	health endpoints.Health
+}
+
+type pooledConn struct {
+	pool    *addressPool
+	sc      balancer.SubConn
+	state   connectivity.State
+	streams int
+}
+
+func (b *clientBalancer) UpdateClientConnState(s balancer.ClientConnState) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if cfg, ok := s.BalancerConfig.(*clientBalancerConfig); ok {
+		b.cfg = cfg
+	}
+	if b.cfg == nil {
+		b.cfg = &clientBalancerConfig{PoolSize: 1, Policy: endpoints.PolicyRoundRobin}
+	}
+
+	addrs := s.ResolverState.Addresses
+	if len(addrs) == 0 {
+		for _, ep := range s.ResolverState.Endpoints {
+			if len(ep.Addresses) > 0 {
+				addrs = append(addrs, ep.Addresses[0])
+			}
+		}
+	}
+	if len(addrs) == 0 {
+		b.resolverErr = errors.New("produced zero addresses")
+		b.updateStateLocked()
+		return balancer.ErrBadResolverState
+	}
+	b.resolverErr = nil
+
+	resolved := resolver.NewAddressMap()
+	for _, addr := range addrs {
+		resolved.Set(addr, nil)
+		pool := &addressPool{}
+		if v, ok := b.pools.Get(addr); ok {
+			pool = v.(*addressPool)
+		}
+		for len(pool.conns) < b.cfg.PoolSize {
+			pc, err := b.newConn(pool, addr)
+			if err != nil {
+				break
+			}
+			pool.conns = append(pool.conns, pc)
+		}
+		for len(pool.conns) > b.cfg.PoolSize {
+			last := pool.conns[len(pool.conns)-1]
+			last.state = connectivity.Shutdown
+			last.sc.Shutdown()
+			pool.conns = pool.conns[:len(pool.conns)-1]
+		}
+		b.pools.Set(addr, pool)
+	}
+	for _, addr := range b.pools.Keys() {
+		if _, ok := resolved.Get(addr); ok {
+			continue
+		}
+		v, _ := b.pools.Get(addr)
+		for _, pc := range v.(*addressPool).conns {
+			pc.state = connectivity.Shutdown
+			pc.sc.Shutdown()
+		}
+		b.pools.Delete(addr)
+	}
+	b.updateStateLocked()
+	return nil
+}
+
+func (b *clientBalancer) newConn(pool *addressPool, addr resolver.Address) (*pooledConn, error) {
+	pc := &pooledConn{pool: pool, state: connectivity.Idle}
+	sc, err := b.cc.NewSubConn([]resolver.Address{addr}, balancer.NewSubConnOptions{
+		StateListener: func(s balancer.SubConnState) {
+			b.updateConnState(pc, s)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	pc.sc = sc
+	sc.Connect()
+	return pc, nil
+}
+
+func (b *clientBalancer) updateConnState(pc *pooledConn, s balancer.SubConnState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if pc.state == connectivity.Shutdown {
+		return
+	}
+	pc.state = s.ConnectivityState
+	if pc.state == connectivity.Idle {
+		pc.sc.Connect()
+	}
+	b.updateStateLocked()
+}
+
+func (b *clientBalancer) ResolverError(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.resolverErr = err
+	if b.pools.Len() == 0 {
+		b.updateStateLocked()
+	}
+}
+
+func (b *clientBalancer) UpdateSubConnState(balancer.SubConn, balancer.SubConnState) {
+	// The state of the connections is received by their StateListener.
+}
+
+func (b *clientBalancer) ExitIdle() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, v := range b.pools.Values() {
+		for _, pc := range v.(*addressPool).conns {
+			if pc.state == connectivity.Idle {
+				pc.sc.Connect()
+			}
+		}
+	}
+}
+
+func (b *clientBalancer) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, v := range b.pools.Values() {
+		for _, pc := range v.(*addressPool).conns {
+			pc.state = connectivity.Shutdown
+			pc.sc.Shutdown()
+		}
+	}
+	b.pools = resolver.NewAddressMap()
+	b.ready = nil
+}
+
+// updateStateLocked aggregates the state of the connections and sends a new picker.
+func (b *clientBalancer) updateStateLocked() {
+	b.ready = b.ready[:0]
+	var connecting, idle bool
+	for _, v := range b.pools.Values() {
+		for _, pc := range v.(*addressPool).conns {
+			switch pc.state {
+			case connectivity.Ready:
+				b.ready = append(b.ready, pc)
+			case connectivity.Connecting:
+				connecting = true
+			case connectivity.Idle:
+				idle = true
+			}
+		}
+	}
+
+	switch {
+	case len(b.ready) > 0:
+		b.state = connectivity.Ready
+	case connecting:
+		b.state = connectivity.Connecting
+	case idle:
+		b.state = connectivity.Idle
+	default:
+		b.state = connectivity.TransientFailure
+	}
+	b.saturated = false
+
+	var picker balancer.Picker = &clientPicker{b: b}
+	if b.state == connectivity.TransientFailure {
+		err := b.resolverErr
+		if err == nil {
+			err = errors.New("no connection is ready")
+		}
+		picker = base.NewErrPicker(balancer.TransientFailureError(err))
+	}
+	b.cc.UpdateState(balancer.State{ConnectivityState: b.state, Picker: picker})
+}
+
+func (b *clientBalancer) pick() (balancer.PickResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.ready) == 0 {
+		return balancer.PickResult{}, balancer.ErrNoSubConnAvailable
+	}
+	now := time.Now()
+	// The ejected addresses are only used when all the addresses are ejected.
+	skipEjected := false
+	for _, pc := range b.ready {
+		if !pc.pool.health.Ejected(now) {
+			skipEjected = true
+			break
+		}
+	}
+	pc := b.chooseLocked(now, skipEjected)
+	if pc == nil {
+		b.saturated = true
+		return balancer.PickResult{}, balancer.ErrNoSubConnAvailable
+	}
+	pc.streams++
+	return balancer.PickResult{
+		SubConn: pc.sc,
+		Done:    func(info balancer.DoneInfo) { b.done(pc, info) },
+	}, nil
+}
+
+// chooseLocked returns the ready connection to use according to the policy, or nil if
+// all of them reached their limit of concurrent RPCs.
+func (b *clientBalancer) chooseLocked(now time.Time, skipEjected bool) *pooledConn {
+	n := len(b.ready)
+	start := b.next % n
+	b.next = start + 1
+	var chosen *pooledConn
+	for i := 0; i < n; i++ {
+		pc := b.ready[(start+i)%n]
+		if skipEjected && pc.pool.health.Ejected(now) {
+			continue
+		}
+		if b.cfg.MaxConcurrentStreams > 0 && pc.streams >= b.cfg.MaxConcurrentStreams {
+			continue
+		}
+		if b.cfg.Policy != endpoints.PolicyLeastOutstanding {
+			return pc
+		}
+		if chosen == nil || pc.streams < chosen.streams {
+			chosen = pc
+		}
+	}
+	return chosen
+}
+
+func (b *clientBalancer) done(pc *pooledConn, info balancer.DoneInfo) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	pc.streams--
+	pc.pool.health.Record(isUnhealthy(info.Err), b.cfg.EjectionFailures, b.cfg.EjectionDuration, time.Now())
+	if b.saturated && pc.state == connectivity.Ready {
+		// Wake up the RPCs waiting for a connection.
+		b.updateStateLocked()
+	}
+}
+
+// isUnhealthy returns whether an RPC failed because of the health of the server.
+func isUnhealthy(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+type clientPicker struct {
+	b *clientBalancer
+}
+
+func (p *clientPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	return p.b.pick()
+}
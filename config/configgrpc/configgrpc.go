@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/mostynb/go-grpc-compression/nonclobbering/snappy"
@@ -27,7 +28,9 @@ import (
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/stats"
 	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/tap"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
@@ -38,10 +41,27 @@ import (
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/config/internal"
+	"go.opentelemetry.io/collector/config/internal/admission"
 	"go.opentelemetry.io/collector/extension/auth"
 )
 
-var errMetadataNotFound = errors.New("no request metadata found")
+var (
+	errMetadataNotFound      = errors.New("no request metadata found")
+	errMemoryLimiterNotFound = errors.New("memory limiter not found")
+	errNotMemoryLimiter      = errors.New("requested extension is not a memory limiter")
+	errTapHandleConflict     = errors.New("memory_limiter can't be used with a grpc.InTapHandle server option")
+)
+
+// healthServicePrefix is the prefix of the methods of the gRPC health checking service,
+// which keep being served while the memory limiter refuses data.
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+// memoryLimiter is implemented by the memory limiter extension.
+type memoryLimiter interface {
+	// MustRefuseBudget returns if the data attributed to the named budget must be refused
+	// because of high memory usage.
+	MustRefuseBudget(name string) bool
+}
 
 // KeepaliveClientConfig exposes the keepalive.ClientParameters to be used by the exporter.
 // Refer to the original data-structure for the meaning of each parameter:
@@ -107,6 +127,14 @@ type ClientConfig struct {
 
 	// Auth configuration for outgoing RPCs.
 	Auth *configauth.Authentication `mapstructure:"auth"`
+
+	// ConnectionPool configures a pool of connections to the endpoint, used in round-robin.
+	// When set, it replaces the load balancer configured by BalancerName.
+	ConnectionPool *ConnectionPoolConfig `mapstructure:"connection_pool"`
+
+	// LoadBalancing configures the balancing of the RPCs between several endpoints.
+	// When set, it replaces Endpoint and the load balancer configured by BalancerName.
+	LoadBalancing *LoadBalancingConfig `mapstructure:"load_balancing"`
 }
 
 // NewDefaultClientConfig returns a new instance of ClientConfig with default values.
@@ -165,6 +193,7 @@ func NewDefaultKeepaliveEnforcementPolicy() *KeepaliveEnforcementPolicy {
 // ServerConfig defines common settings for a gRPC server configuration.
 type ServerConfig struct {
 	// Server net.Addr config. For transport only "tcp" and "unix" are valid options.
+	// An endpoint of the form "unix:///path/to/socket" listens on the Unix domain socket at this path.
 	NetAddr confignet.AddrConfig `mapstructure:",squash"`
 
 	// Configures the protocol to use TLS.
@@ -192,10 +221,36 @@ type ServerConfig struct {
 	// Auth for this receiver
 	Auth *configauth.Authentication `mapstructure:"auth"`
 
+	// MemoryLimiter is the ID of the memory limiter extension to consult before reading
+	// the RPCs. RPCs are rejected with RESOURCE_EXHAUSTED while it refuses data. It is
+	// checked by a tap handle, so ToServer fails if a grpc.InTapHandle option is also given.
+	MemoryLimiter *component.ID `mapstructure:"memory_limiter"`
+
+	// MemoryLimiterBudget is the name of the budget of the memory limiter extension the RPCs
+	// are attributed to. By default, the default budget of the extension is used.
+	MemoryLimiterBudget string `mapstructure:"memory_limiter_budget"`
+
+	// Admission bounds the bytes of the unary RPCs processed concurrently by the server, once
+	// their request is read and decoded. The memory used to read and decode the requests is
+	// bounded by MaxRecvMsgSizeMiB and MemoryLimiter instead. Streaming RPCs aren't admitted.
+	Admission *AdmissionConfig `mapstructure:"admission"`
+
 	// Include propagates the incoming connection's metadata to downstream consumers.
 	IncludeMetadata bool `mapstructure:"include_metadata"`
 }
 
+// AdmissionConfig defines the admission control of the unary RPCs by the size of their request,
+// which applies to their processing after the request is decoded.
+type AdmissionConfig struct {
+	// RequestLimitMiB is the maximum amount of bytes, in MiB, of the uncompressed requests
+	// admitted and being processed. Zero disables admission control.
+	RequestLimitMiB uint64 `mapstructure:"request_limit_mib"`
+
+	// WaitingLimitMiB is the maximum amount of bytes, in MiB, of the requests waiting
+	// to be admitted. RPCs beyond are rejected with RESOURCE_EXHAUSTED.
+	WaitingLimitMiB uint64 `mapstructure:"waiting_limit_mib"`
+}
+
 // NewDefaultServerConfig returns a new instance of ServerConfig with default values.
 func NewDefaultServerConfig() *ServerConfig {
 	return &ServerConfig{
@@ -263,8 +318,12 @@ func (gcs *ClientConfig) ToClientConn(
 	if err != nil {
 		return nil, err
 	}
+	target := gcs.sanitizedEndpoint()
+	if gcs.LoadBalancing != nil {
+		target = loadBalancingTarget(gcs.LoadBalancing)
+	}
 	//nolint:staticcheck //SA1019 see https://github.com/open-telemetry/opentelemetry-collector/pull/11575
-	return grpc.DialContext(ctx, gcs.sanitizedEndpoint(), grpcOpts...)
+	return grpc.DialContext(ctx, target, grpcOpts...)
 }
 
 func (gcs *ClientConfig) getGrpcDialOptions(
@@ -328,7 +387,13 @@ func (gcs *ClientConfig) getGrpcDialOptions(
 		opts = append(opts, grpc.WithPerRPCCredentials(perRPCCredentials))
 	}
 
-	if gcs.BalancerName != "" {
+	if gcs.LoadBalancing != nil {
+		opts = append(opts, grpc.WithResolvers(&endpointsResolverBuilder{cfg: gcs.LoadBalancing, logger: settings.Logger}))
+	}
+	switch {
+	case gcs.ConnectionPool != nil, gcs.LoadBalancing != nil:
+		opts = append(opts, grpc.WithDefaultServiceConfig(clientBalancerServiceConfig(gcs.ConnectionPool, gcs.LoadBalancing)))
+	case gcs.BalancerName != "":
 		opts = append(opts, grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingPolicy":"%s"}`, gcs.BalancerName)))
 	}
 
@@ -396,9 +461,23 @@ func (gss *ServerConfig) ToServer(
 	if err != nil {
 		return nil, err
 	}
+	if gss.MemoryLimiter != nil {
+		return newServerWithTapHandle(grpcOpts)
+	}
 	return grpc.NewServer(grpcOpts...), nil
 }
 
+// newServerWithTapHandle creates a server whose options include the tap handle of the memory limiter.
+// gRPC panics when another tap handle is set by the extra options, which is returned as an error.
+func newServerWithTapHandle(opts []grpc.ServerOption) (srv *grpc.Server, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", errTapHandleConflict, r)
+		}
+	}()
+	return grpc.NewServer(opts...), nil
+}
+
 func (gss *ServerConfig) getGrpcServerOptions(
 	host component.Host,
 	settings component.TelemetrySettings,
@@ -463,6 +542,18 @@ func (gss *ServerConfig) getGrpcServerOptions(
 		}
 	}
 
+	if gss.MemoryLimiter != nil {
+		limiter, err := getMemoryLimiter(host.GetExtensions(), *gss.MemoryLimiter)
+		if err != nil {
+			return nil, err
+		}
+
+		// The tap handle runs before the stream is created, so before any message is read.
+		opts = append(opts, grpc.InTapHandle(func(ctx context.Context, info *tap.Info) (context.Context, error) {
+			return memoryLimiterTapHandle(ctx, info, limiter, gss.MemoryLimiterBudget)
+		}))
+	}
+
 	var uInterceptors []grpc.UnaryServerInterceptor
 	var sInterceptors []grpc.StreamServerInterceptor
 
@@ -480,6 +571,27 @@ func (gss *ServerConfig) getGrpcServerOptions(
 		})
 	}
 
+	if gss.Admission != nil && gss.Admission.RequestLimitMiB > 0 {
+		// nolint:gosec
+		queue, err := admission.NewBoundedQueue(
+			int64(gss.Admission.RequestLimitMiB*1024*1024),
+			int64(gss.Admission.WaitingLimitMiB*1024*1024),
+			settings.MeterProvider,
+			"go.opentelemetry.io/collector/config/configgrpc",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// The size of the requests is only known once read, which is reported to the stats handlers,
+		// and gRPC decodes the requests before the unary interceptors are called: admission only
+		// bounds the processing of the decoded requests, not their reading nor their decoding.
+		opts = append(opts, grpc.StatsHandler(requestSizeStatsHandler{}))
+		uInterceptors = append(uInterceptors, func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+			return admissionUnaryServerInterceptor(ctx, req, info, handler, queue)
+		})
+	}
+
 	otelOpts := []otelgrpc.Option{
 		otelgrpc.WithTracerProvider(settings.TracerProvider),
 		otelgrpc.WithPropagators(otel.GetTextMapPropagator()),
@@ -578,6 +690,63 @@ func authStreamServerInterceptor(srv any, stream grpc.ServerStream, _ *grpc.Stre
 	return handler(srv, wrapServerStream(ctx, stream))
 }
 
+// getMemoryLimiter selects the memory limiter extension with the given id from the list of extensions.
+func getMemoryLimiter(extensions map[component.ID]component.Component, id component.ID) (memoryLimiter, error) {
+	if ext, found := extensions[id]; found {
+		if limiter, ok := ext.(memoryLimiter); ok {
+			return limiter, nil
+		}
+		return nil, errNotMemoryLimiter
+	}
+	return nil, fmt.Errorf("failed to resolve memory limiter %q: %w", id, errMemoryLimiterNotFound)
+}
+
+func memoryLimiterTapHandle(ctx context.Context, info *tap.Info, limiter memoryLimiter, budget string) (context.Context, error) {
+	if limiter.MustRefuseBudget(budget) && !strings.HasPrefix(info.FullMethodName, healthServicePrefix) {
+		return ctx, status.Error(codes.ResourceExhausted, "data refused due to high memory usage")
+	}
+	return ctx, nil
+}
+
+type requestSizeKey struct{}
+
+// requestSizeStatsHandler records the size of the requests of the RPCs in their context.
+type requestSizeStatsHandler struct{}
+
+func (requestSizeStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, requestSizeKey{}, new(atomic.Int64))
+}
+
+func (requestSizeStatsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if in, ok := s.(*stats.InPayload); ok {
+		if size, ok := ctx.Value(requestSizeKey{}).(*atomic.Int64); ok {
+			size.Add(int64(in.Length))
+		}
+	}
+}
+
+func (requestSizeStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (requestSizeStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+func admissionUnaryServerInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler, queue *admission.BoundedQueue) (any, error) {
+	var size int64
+	if s, ok := ctx.Value(requestSizeKey{}).(*atomic.Int64); ok {
+		size = s.Load()
+	}
+	release, err := queue.Acquire(ctx, size)
+	switch {
+	case errors.Is(err, admission.ErrRequestTooLarge), errors.Is(err, admission.ErrTooMuchWaiting):
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	case err != nil:
+		return nil, status.FromContextError(err).Err()
+	}
+	defer release()
+	return handler(ctx, req)
+}
+
 func getLeveledMeterProvider(settings component.TelemetrySettings) metric.MeterProvider {
 	if configtelemetry.LevelDetailed <= settings.MetricsLevel {
 		return settings.MeterProvider
@@ -10,6 +10,8 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -19,9 +21,12 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/tap"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
@@ -33,6 +38,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/extension/auth"
 	"go.opentelemetry.io/collector/extension/auth/authtest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 )
 
@@ -376,6 +382,183 @@ func TestGrpcServerAuthSettings(t *testing.T) {
 	assert.NotNil(t, srv)
 }
 
+type mockMemoryLimiter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	mustRefuse atomic.Bool
+	budget     string
+}
+
+func (ml *mockMemoryLimiter) MustRefuseBudget(name string) bool {
+	return ml.mustRefuse.Load() && name == ml.budget
+}
+
+func TestGrpcServerMemoryLimiter(t *testing.T) {
+	limiter := &mockMemoryLimiter{budget: "traces"}
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+		},
+		MemoryLimiter:       &mockID,
+		MemoryLimiterBudget: "traces",
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			mockID: limiter,
+		},
+	}
+	ln, err := gss.NetAddr.Listen(context.Background())
+	require.NoError(t, err)
+	srv, err := gss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	gcs := &ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, grpcClientConn.Close()) })
+	c := ptraceotlp.NewGRPCClient(grpcClientConn)
+
+	_, err = c.Export(context.Background(), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	require.NoError(t, err)
+
+	limiter.mustRefuse.Store(true)
+	_, err = c.Export(context.Background(), ptraceotlp.NewExportRequest())
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Health checks keep being served.
+	resp, err := healthpb.NewHealthClient(grpcClientConn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+}
+
+func TestGrpcServerInvalidMemoryLimiter(t *testing.T) {
+	nonExistingID := component.MustNewID("nonexisting")
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint: "0.0.0.0:1234",
+		},
+		MemoryLimiter: &nonExistingID,
+	}
+	_, err := gss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.ErrorIs(t, err, errMemoryLimiterNotFound)
+
+	gss.MemoryLimiter = &mockID
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			mockID: auth.NewServer(),
+		},
+	}
+	_, err = gss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings())
+	require.ErrorIs(t, err, errNotMemoryLimiter)
+}
+
+func TestGrpcServerMemoryLimiterTapHandleConflict(t *testing.T) {
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint: "localhost:0",
+		},
+		MemoryLimiter: &mockID,
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			mockID: &mockMemoryLimiter{},
+		},
+	}
+	tapHandle := WithGrpcServerOption(grpc.InTapHandle(func(ctx context.Context, _ *tap.Info) (context.Context, error) {
+		return ctx, nil
+	}))
+	_, err := gss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings(), tapHandle)
+	require.ErrorIs(t, err, errTapHandleConflict)
+
+	gss.MemoryLimiter = nil
+	srv, err := gss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings(), tapHandle)
+	require.NoError(t, err)
+	srv.Stop()
+}
+
+type blockingTraceServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	processing chan struct{}
+	done       chan struct{}
+}
+
+func (bts *blockingTraceServer) Export(context.Context, ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	bts.processing <- struct{}{}
+	<-bts.done
+	return ptraceotlp.NewExportResponse(), nil
+}
+
+func TestGrpcServerAdmission(t *testing.T) {
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+		},
+		Admission: &AdmissionConfig{
+			RequestLimitMiB: 1,
+		},
+	}
+	ln, err := gss.NetAddr.Listen(context.Background())
+	require.NoError(t, err)
+	srv, err := gss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	traceServer := &blockingTraceServer{processing: make(chan struct{}), done: make(chan struct{})}
+	ptraceotlp.RegisterGRPCServer(srv, traceServer)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	gcs := &ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, grpcClientConn.Close()) })
+	c := ptraceotlp.NewGRPCClient(grpcClientConn)
+
+	newRequest := func(size int) ptraceotlp.ExportRequest {
+		td := ptrace.NewTraces()
+		td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("data", strings.Repeat("x", size))
+		return ptraceotlp.NewExportRequestFromTraces(td)
+	}
+
+	exported := make(chan error)
+	go func() {
+		_, err := c.Export(context.Background(), newRequest(600*1024), grpc.WaitForReady(true))
+		exported <- err
+	}()
+	<-traceServer.processing
+
+	// No request can wait while the first one is processed.
+	_, err = c.Export(context.Background(), newRequest(600*1024))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	// Requests larger than the limit are never admitted.
+	_, err = c.Export(context.Background(), newRequest(2*1024*1024))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	close(traceServer.done)
+	require.NoError(t, <-exported)
+	go func() { <-traceServer.processing }()
+	_, err = c.Export(context.Background(), newRequest(600*1024))
+	require.NoError(t, err)
+}
+
 func TestGrpcClientConfigInvalidBalancer(t *testing.T) {
 	settings := ClientConfig{
 		Headers: map[string]configopaque.String{
@@ -822,6 +1005,49 @@ func TestReceiveOnUnixDomainSocket(t *testing.T) {
 	srv.Stop()
 }
 
+func TestReceiveOnUnixEndpoint(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	socketName := tempSocketName(t)
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:   "unix://" + socketName,
+			Transport:  confignet.TransportTypeTCP,
+			UnixSocket: confignet.UnixSocketConfig{Permissions: "0600"},
+		},
+	}
+	ln, err := gss.NetAddr.Listen(context.Background())
+	require.NoError(t, err)
+	fi, err := os.Stat(socketName)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+	srv, err := gss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+
+	gcs := &ClientConfig{
+		Endpoint: "unix://" + socketName,
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	grpcClientConn, errClient := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, errClient)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+	c := ptraceotlp.NewGRPCClient(grpcClientConn)
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	resp, errResp := c.Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	require.NoError(t, errResp)
+	assert.NotNil(t, resp)
+	cancelFunc()
+	srv.Stop()
+}
+
 func TestContextWithClient(t *testing.T) {
 	testCases := []struct {
 		desc       string
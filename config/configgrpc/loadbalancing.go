@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/resolver"
+
+	"go.opentelemetry.io/collector/config/internal/endpoints"
+)
+
+// loadBalancingScheme is the scheme of the target resolved by the endpointsResolver.
+const loadBalancingScheme = "otelcol-lb"
+
+// LoadBalancingConfig configures the balancing of the RPCs between several endpoints.
+// The RPCs failing with UNAVAILABLE or DEADLINE_EXCEEDED count as failures for the ejection.
+type LoadBalancingConfig = endpoints.LoadBalancingConfig
+
+// EjectionConfig configures the ejection of the addresses whose RPCs fail repeatedly.
+type EjectionConfig = endpoints.EjectionConfig
+
+// loadBalancingTarget returns the target resolved by the endpointsResolver. Its endpoint,
+// the first one configured, is the default authority of the RPCs.
+func loadBalancingTarget(cfg *LoadBalancingConfig) string {
+	return loadBalancingScheme + ":///" + cfg.Endpoints[0]
+}
+
+// endpointsResolverBuilder builds the resolver of the endpoints of a LoadBalancingConfig.
+type endpointsResolverBuilder struct {
+	cfg    *LoadBalancingConfig
+	logger *zap.Logger
+}
+
+func (b *endpointsResolverBuilder) Build(_ resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &endpointsResolver{
+		cfg:     b.cfg,
+		logger:  b.logger,
+		cc:      cc,
+		cancel:  cancel,
+		resolve: make(chan struct{}, 1),
+	}
+	if b.cfg.Resolver != endpoints.ResolverDNS {
+		r.update(ctx)
+		return r, nil
+	}
+	r.lookup = net.DefaultResolver.LookupHost
+	r.update(ctx)
+	r.wg.Add(1)
+	go r.refresh(ctx)
+	return r, nil
+}
+
+func (b *endpointsResolverBuilder) Scheme() string {
+	return loadBalancingScheme
+}
+
+// endpointsResolver resolves the endpoints to addresses, periodically with the "dns" resolver.
+type endpointsResolver struct {
+	cfg    *LoadBalancingConfig
+	logger *zap.Logger
+	cc     resolver.ClientConn
+	lookup endpoints.LookupHostFunc
+
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	resolve chan struct{}
+}
+
+func (r *endpointsResolver) refresh(ctx context.Context) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.cfg.RefreshIntervalOrDefault())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.resolve:
+		}
+		r.update(ctx)
+	}
+}
+
+func (r *endpointsResolver) update(ctx context.Context) {
+	addrs, err := endpoints.Resolve(ctx, r.cfg.Endpoints, r.lookup)
+	if len(addrs) == 0 {
+		r.cc.ReportError(fmt.Errorf("no address resolved for the endpoints: %w", err))
+		return
+	}
+	if err != nil {
+		r.logger.Warn("Failed to resolve some load balancing endpoints", zap.Error(err))
+	}
+	state := resolver.State{Addresses: make([]resolver.Address, 0, len(addrs))}
+	for _, addr := range addrs {
+		state.Addresses = append(state.Addresses, resolver.Address{Addr: addr.Addr, ServerName: addr.Host})
+	}
+	if err = r.cc.UpdateState(state); err != nil {
+		r.logger.Debug("Failed to update the load balancing addresses", zap.Error(err))
+	}
+}
+
+// ResolveNow resolves the endpoints again, when the connection to an address failed.
+func (r *endpointsResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolve <- struct{}{}:
+	default:
+	}
+}
+
+func (r *endpointsResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
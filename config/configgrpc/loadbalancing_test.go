@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// countingTraceServer counts its requests, failing them with UNAVAILABLE while failing is set,
+// and blocking them until unblocked while blocking is set.
+type countingTraceServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	requests  atomic.Int64
+	failing   atomic.Bool
+	blocking  atomic.Bool
+	unblocked chan struct{}
+}
+
+func (cts *countingTraceServer) Export(context.Context, ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	cts.requests.Add(1)
+	if cts.blocking.Load() {
+		<-cts.unblocked
+	}
+	if cts.failing.Load() {
+		return ptraceotlp.NewExportResponse(), status.Error(codes.Unavailable, "unavailable")
+	}
+	return ptraceotlp.NewExportResponse(), nil
+}
+
+// startLoadBalancingTestServers starts a gRPC server for each trace server and returns
+// their endpoints.
+func startLoadBalancingTestServers(t *testing.T, traceServers ...*countingTraceServer) []string {
+	var endpoints []string
+	for _, traceServer := range traceServers {
+		cfg := startPoolTestServer(t, func(srv *grpc.Server) {
+			ptraceotlp.RegisterGRPCServer(srv, traceServer)
+		})
+		endpoints = append(endpoints, cfg.Endpoint)
+	}
+	return endpoints
+}
+
+// newLoadBalancingTestClient returns a client balancing between the servers, once
+// connected to all of them. The requests of the servers are reset.
+func newLoadBalancingTestClient(t *testing.T, lb *LoadBalancingConfig, servers ...*countingTraceServer) ptraceotlp.GRPCClient {
+	lb.Endpoints = startLoadBalancingTestServers(t, servers...)
+	gcs := &ClientConfig{
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+		LoadBalancing: lb,
+	}
+	require.NoError(t, gcs.Validate())
+	require.NoError(t, lb.Validate())
+	conn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	c := ptraceotlp.NewGRPCClient(conn)
+
+	// The connections to the servers become ready independently.
+	require.Eventually(t, func() bool {
+		_, err = c.Export(context.Background(), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+		assert.NoError(t, err)
+		for _, server := range servers {
+			if server.requests.Load() == 0 {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+	for _, server := range servers {
+		server.requests.Store(0)
+	}
+	return c
+}
+
+func TestLoadBalancingRoundRobin(t *testing.T) {
+	servers := []*countingTraceServer{{}, {}}
+	c := newLoadBalancingTestClient(t, &LoadBalancingConfig{}, servers...)
+
+	for i := 0; i < 10; i++ {
+		_, err := c.Export(context.Background(), ptraceotlp.NewExportRequest())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int64(5), servers[0].requests.Load())
+	assert.Equal(t, int64(5), servers[1].requests.Load())
+}
+
+func TestLoadBalancingLeastOutstanding(t *testing.T) {
+	servers := []*countingTraceServer{{unblocked: make(chan struct{})}, {}}
+	c := newLoadBalancingTestClient(t, &LoadBalancingConfig{Policy: "least_outstanding"}, servers...)
+	servers[0].blocking.Store(true)
+
+	// Keep a RPC in progress on the first server.
+	exported := make(chan error)
+	go func() {
+		_, err := c.Export(context.Background(), ptraceotlp.NewExportRequest())
+		exported <- err
+	}()
+	go func() {
+		_, err := c.Export(context.Background(), ptraceotlp.NewExportRequest())
+		exported <- err
+	}()
+	require.Eventually(t, func() bool {
+		return servers[0].requests.Load() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, <-exported)
+
+	for i := 0; i < 5; i++ {
+		_, err := c.Export(context.Background(), ptraceotlp.NewExportRequest())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int64(1), servers[0].requests.Load())
+	assert.Equal(t, int64(6), servers[1].requests.Load())
+
+	close(servers[0].unblocked)
+	require.NoError(t, <-exported)
+}
+
+func TestLoadBalancingEjection(t *testing.T) {
+	servers := []*countingTraceServer{{}, {}}
+	c := newLoadBalancingTestClient(t, &LoadBalancingConfig{
+		Ejection: &EjectionConfig{ConsecutiveFailures: 2, Duration: time.Hour},
+	}, servers...)
+	servers[0].failing.Store(true)
+
+	var failures int
+	for i := 0; i < 10; i++ {
+		if _, err := c.Export(context.Background(), ptraceotlp.NewExportRequest()); err != nil {
+			failures++
+		}
+	}
+	// The failing server is ejected after 2 failed RPCs.
+	assert.Equal(t, 2, failures)
+	assert.Equal(t, int64(2), servers[0].requests.Load())
+	assert.Equal(t, int64(8), servers[1].requests.Load())
+
+	// All the addresses are used when all of them are ejected.
+	servers[1].failing.Store(true)
+	for i := 0; i < 2; i++ {
+		_, err := c.Export(context.Background(), ptraceotlp.NewExportRequest())
+		require.Error(t, err)
+	}
+	servers[0].failing.Store(false)
+	servers[1].failing.Store(false)
+	for i := 0; i < 2; i++ {
+		_, err := c.Export(context.Background(), ptraceotlp.NewExportRequest())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int64(3), servers[0].requests.Load())
+	assert.Equal(t, int64(11), servers[1].requests.Load())
+}
+
+func TestLoadBalancingDNS(t *testing.T) {
+	servers := []*countingTraceServer{{}}
+	endpoint := startLoadBalancingTestServers(t, servers...)[0]
+	_, port, err := net.SplitHostPort(endpoint)
+	require.NoError(t, err)
+
+	gcs := &ClientConfig{
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+		LoadBalancing: &LoadBalancingConfig{
+			Endpoints:       []string{net.JoinHostPort("localhost", port)},
+			Resolver:        "dns",
+			RefreshInterval: 10 * time.Millisecond,
+		},
+	}
+	conn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	c := ptraceotlp.NewGRPCClient(conn)
+
+	for i := 0; i < 3; i++ {
+		_, err = c.Export(context.Background(), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int64(3), servers[0].requests.Load())
+}
+
+func TestLoadBalancingUnresolvedEndpoints(t *testing.T) {
+	r := &endpointsResolver{
+		cfg: &LoadBalancingConfig{Endpoints: []string{"backend:4317"}},
+		lookup: func(context.Context, string) ([]string, error) {
+			return nil, errors.New("no such host")
+		},
+	}
+	cc := &testResolverClientConn{}
+	r.cc = cc
+	r.update(context.Background())
+	assert.ErrorContains(t, cc.err, `no address resolved for the endpoints: failed to resolve "backend": no such host`)
+}
+
+type testResolverClientConn struct {
+	resolver.ClientConn
+	err error
+}
+
+func (cc *testResolverClientConn) ReportError(err error) {
+	cc.err = err
+}
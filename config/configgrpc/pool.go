@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"errors"
+)
+
+// ConnectionPoolConfig configures a pool of connections to each address of the endpoint.
+// The RPCs are balanced between all the connections.
+type ConnectionPoolConfig struct {
+	// Size is the number of connections to each address. Default is 1.
+	Size int `mapstructure:"size"`
+
+	// MaxConcurrentStreams is the maximum number of concurrent RPCs on each connection.
+	// RPCs wait for a connection with less concurrent RPCs when all the connections reach
+	// this limit. Default is 0, which means no limit.
+	MaxConcurrentStreams int `mapstructure:"max_concurrent_streams"`
+}
+
+// Validate checks if the connection pool configuration is valid.
+func (cfg *ConnectionPoolConfig) Validate() error {
+	if cfg.Size < 0 {
+		return errors.New("connection pool size must be non-negative")
+	}
+	if cfg.MaxConcurrentStreams < 0 {
+		return errors.New("connection pool max_concurrent_streams must be non-negative")
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func TestConnectionPoolConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ConnectionPoolConfig
+		err  string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name: "valid",
+			cfg:  ConnectionPoolConfig{Size: 4, MaxConcurrentStreams: 100},
+		},
+		{
+			name: "negative size",
+			cfg:  ConnectionPoolConfig{Size: -1},
+			err:  "connection pool size must be non-negative",
+		},
+		{
+			name: "negative max concurrent streams",
+			cfg:  ConnectionPoolConfig{MaxConcurrentStreams: -1},
+			err:  "connection pool max_concurrent_streams must be non-negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+type peerTraceServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	mu    sync.Mutex
+	peers map[string]int
+}
+
+func (pts *peerTraceServer) Export(ctx context.Context, _ ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	p, _ := peer.FromContext(ctx)
+	pts.mu.Lock()
+	defer pts.mu.Unlock()
+	pts.peers[p.Addr.String()]++
+	return ptraceotlp.NewExportResponse(), nil
+}
+
+// startPoolTestServer starts a gRPC server with the given services and returns
+// the configuration of a client connecting to it.
+func startPoolTestServer(t *testing.T, register func(*grpc.Server)) *ClientConfig {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	register(srv)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+	return &ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+}
+
+func TestClientConnPoolRoundRobin(t *testing.T) {
+	traceServer := &peerTraceServer{peers: map[string]int{}}
+	gcs := startPoolTestServer(t, func(srv *grpc.Server) {
+		ptraceotlp.RegisterGRPCServer(srv, traceServer)
+	})
+	gcs.ConnectionPool = &ConnectionPoolConfig{Size: 3}
+
+	conn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	c := ptraceotlp.NewGRPCClient(conn)
+
+	// The connections of the pool become ready independently.
+	require.Eventually(t, func() bool {
+		_, err = c.Export(context.Background(), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+		assert.NoError(t, err)
+		traceServer.mu.Lock()
+		defer traceServer.mu.Unlock()
+		return len(traceServer.peers) == 3
+	}, 10*time.Second, 10*time.Millisecond)
+
+	traceServer.mu.Lock()
+	clear(traceServer.peers)
+	traceServer.mu.Unlock()
+	for i := 0; i < 6; i++ {
+		_, err = c.Export(context.Background(), ptraceotlp.NewExportRequest())
+		require.NoError(t, err)
+	}
+
+	traceServer.mu.Lock()
+	defer traceServer.mu.Unlock()
+	assert.Len(t, traceServer.peers, 3)
+	for addr, count := range traceServer.peers {
+		assert.Equal(t, 2, count, addr)
+	}
+}
+
+func TestClientConnPoolDefaultSize(t *testing.T) {
+	traceServer := &peerTraceServer{peers: map[string]int{}}
+	gcs := startPoolTestServer(t, func(srv *grpc.Server) {
+		ptraceotlp.RegisterGRPCServer(srv, traceServer)
+	})
+
+	conn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	c := ptraceotlp.NewGRPCClient(conn)
+
+	for i := 0; i < 3; i++ {
+		_, err = c.Export(context.Background(), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+		require.NoError(t, err)
+	}
+
+	traceServer.mu.Lock()
+	defer traceServer.mu.Unlock()
+	assert.Len(t, traceServer.peers, 1)
+}
+
+func TestClientConnPoolMaxConcurrentStreams(t *testing.T) {
+	traceServer := &blockingTraceServer{processing: make(chan struct{}), done: make(chan struct{})}
+	gcs := startPoolTestServer(t, func(srv *grpc.Server) {
+		ptraceotlp.RegisterGRPCServer(srv, traceServer)
+	})
+	gcs.ConnectionPool = &ConnectionPoolConfig{Size: 1, MaxConcurrentStreams: 1}
+
+	conn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	c := ptraceotlp.NewGRPCClient(conn)
+
+	exported := make(chan error)
+	go func() {
+		_, err := c.Export(context.Background(), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+		exported <- err
+	}()
+	<-traceServer.processing
+
+	// The only connection is busy, so the RPC waits until its context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.Export(ctx, ptraceotlp.NewExportRequest())
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	// The waiting RPC is sent once the first one ends.
+	go func() {
+		_, err := c.Export(context.Background(), ptraceotlp.NewExportRequest())
+		exported <- err
+	}()
+	close(traceServer.done)
+	require.NoError(t, <-exported)
+	<-traceServer.processing
+	require.NoError(t, <-exported)
+}
+
+func TestClientConnPoolStreamRelease(t *testing.T) {
+	gcs := startPoolTestServer(t, func(srv *grpc.Server) {
+		healthpb.RegisterHealthServer(srv, health.NewServer())
+	})
+	gcs.ConnectionPool = &ConnectionPoolConfig{Size: 1, MaxConcurrentStreams: 1}
+
+	conn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	c := healthpb.NewHealthClient(conn)
+
+	streamCtx, cancelStream := context.WithCancel(context.Background())
+	stream, err := c.Watch(streamCtx, &healthpb.HealthCheckRequest{}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	// The stream counts against the limit while it is open.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	cancelStream()
+	resp, err := c.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+}
@@ -27,10 +27,12 @@ import (
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp/internal"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/config/configtls"
 	configinternal "go.opentelemetry.io/collector/config/internal"
+	"go.opentelemetry.io/collector/config/internal/admission"
 	"go.opentelemetry.io/collector/extension/auth"
 )
 
@@ -41,9 +43,23 @@ const (
 
 var defaultCompressionAlgorithms = []string{"", "gzip", "zstd", "zlib", "snappy", "deflate", "lz4"}
 
+var (
+	errMemoryLimiterNotFound = errors.New("memory limiter not found")
+	errNotMemoryLimiter      = errors.New("requested extension is not a memory limiter")
+)
+
+// memoryLimiter is implemented by the memory limiter extension.
+type memoryLimiter interface {
+	// MustRefuseBudget returns if the data attributed to the named budget must be refused
+	// because of high memory usage.
+	MustRefuseBudget(name string) bool
+}
+
 // ClientConfig defines settings for creating an HTTP client.
 type ClientConfig struct {
 	// The target URL to send data to (e.g.: http://some.url:9411/v1/traces).
+	// With an endpoint of the form "unix:///path/to/socket", all the requests are sent
+	// through the Unix domain socket at this path, whatever the host of their URL.
 	Endpoint string `mapstructure:"endpoint"`
 
 	// ProxyURL setting for the collector
@@ -111,6 +127,9 @@ type ClientConfig struct {
 	HTTP2PingTimeout time.Duration `mapstructure:"http2_ping_timeout"`
 	// Cookies configures the cookie management of the HTTP client.
 	Cookies *CookiesConfig `mapstructure:"cookies"`
+
+	// LoadBalancing configures the balancing of the requests between several endpoints.
+	LoadBalancing *LoadBalancingConfig `mapstructure:"load_balancing"`
 }
 
 // CookiesConfig defines the configuration of the HTTP client regarding cookies served by the server.
@@ -138,8 +157,19 @@ func NewDefaultClientConfig() ClientConfig {
 	}
 }
 
+// toClientOptions has options that change the behavior of the HTTP client
+// returned by ClientConfig.ToClient().
+type toClientOptions = internal.ToClientOptions
+
+// ToClientOption is an option to change the behavior of the HTTP client
+// returned by ClientConfig.ToClient().
+type ToClientOption = internal.ToClientOption
+
 // ToClient creates an HTTP client.
-func (hcs *ClientConfig) ToClient(ctx context.Context, host component.Host, settings component.TelemetrySettings) (*http.Client, error) {
+func (hcs *ClientConfig) ToClient(ctx context.Context, host component.Host, settings component.TelemetrySettings, opts ...ToClientOption) (*http.Client, error) {
+	clientOpts := &toClientOptions{}
+	clientOpts.Apply(opts...)
+
 	tlsCfg, err := hcs.TLSSetting.LoadTLSConfig(ctx)
 	if err != nil {
 		return nil, err
@@ -182,6 +212,18 @@ func (hcs *ClientConfig) ToClient(ctx context.Context, host component.Host, sett
 
 	transport.DisableKeepAlives = hcs.DisableKeepAlives
 
+	socketPath, isUnix := confignet.UnixSocketPath(hcs.Endpoint)
+	if isUnix {
+		if hcs.ProxyURL != "" {
+			return nil, errors.New("proxy_url cannot be used with a unix endpoint")
+		}
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socketPath)
+		}
+	}
+
 	if hcs.HTTP2ReadIdleTimeout > 0 {
 		transport2, transportErr := http2.ConfigureTransports(transport)
 		if transportErr != nil {
@@ -193,6 +235,19 @@ func (hcs *ClientConfig) ToClient(ctx context.Context, host component.Host, sett
 
 	clientTransport := (http.RoundTripper)(transport)
 
+	// The balancing RoundTripper only changes the address requests are sent to,
+	// so it can be below the Auth RoundTripper.
+	switch {
+	case isUnix && hcs.LoadBalancing != nil:
+		return nil, errors.New("load_balancing cannot be used with a unix endpoint")
+	case clientOpts.Transport != nil:
+		if clientTransport, err = clientOpts.Transport(tlsCfg); err != nil {
+			return nil, err
+		}
+	case hcs.LoadBalancing != nil:
+		clientTransport = newBalancingRoundTripper(transport, hcs.LoadBalancing, settings.Logger)
+	}
+
 	// The Auth RoundTripper should always be the innermost to ensure that
 	// request signing-based auth mechanisms operate after compression
 	// and header middleware modifies the request
@@ -278,9 +333,13 @@ func (interceptor *headerRoundTripper) RoundTrip(req *http.Request) (*http.Respo
 
 // ServerConfig defines settings for creating an HTTP server.
 type ServerConfig struct {
-	// Endpoint configures the listening address for the server.
+	// Endpoint configures the listening address for the server. An endpoint of the form
+	// "unix:///path/to/socket" listens on the Unix domain socket at this path.
 	Endpoint string `mapstructure:"endpoint"`
 
+	// UnixSocket configures the socket file created when listening on a Unix domain socket.
+	UnixSocket *confignet.UnixSocketConfig `mapstructure:"unix_socket"`
+
 	// TLSSetting struct exposes TLS client configuration.
 	TLSSetting *configtls.ServerConfig `mapstructure:"tls"`
 
@@ -290,6 +349,17 @@ type ServerConfig struct {
 	// Auth for this receiver
 	Auth *AuthConfig `mapstructure:"auth"`
 
+	// MemoryLimiter is the ID of the memory limiter extension to consult before reading
+	// the requests. Requests are rejected with 503 Service Unavailable while it refuses data.
+	MemoryLimiter *component.ID `mapstructure:"memory_limiter"`
+
+	// MemoryLimiterBudget is the name of the budget of the memory limiter extension the requests
+	// are attributed to. By default, the default budget of the extension is used.
+	MemoryLimiterBudget string `mapstructure:"memory_limiter_budget"`
+
+	// Admission bounds the bytes of the requests processed concurrently by the server.
+	Admission *AdmissionConfig `mapstructure:"admission"`
+
 	// MaxRequestBodySize sets the maximum request body size in bytes. Default: 20MiB.
 	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
 
@@ -349,6 +419,19 @@ func NewDefaultServerConfig() ServerConfig {
 	}
 }
 
+// AdmissionConfig defines the admission control of the requests by their size.
+type AdmissionConfig struct {
+	// RequestLimitMiB is the maximum amount of bytes, in MiB, of the requests admitted
+	// and being processed. Requests are sized by their Content-Length, or by the
+	// max request body size when it is unknown, so it must not be lower than the max request
+	// body size. Zero disables admission control.
+	RequestLimitMiB uint64 `mapstructure:"request_limit_mib"`
+
+	// WaitingLimitMiB is the maximum amount of bytes, in MiB, of the requests waiting
+	// to be admitted. Requests beyond are rejected with 429 Too Many Requests.
+	WaitingLimitMiB uint64 `mapstructure:"waiting_limit_mib"`
+}
+
 type AuthConfig struct {
 	// Auth for this receiver.
 	configauth.Authentication `mapstructure:",squash"`
@@ -358,9 +441,30 @@ type AuthConfig struct {
 	RequestParameters []string `mapstructure:"request_params"`
 }
 
+// Validate checks that the requests of unknown size, sized by the max request body size,
+// can be admitted.
+func (hss *ServerConfig) Validate() error {
+	if hss.Admission == nil || hss.Admission.RequestLimitMiB == 0 {
+		return nil
+	}
+	maxRequestBodySize := hss.MaxRequestBodySize
+	if maxRequestBodySize <= 0 {
+		maxRequestBodySize = defaultMaxRequestBodySize
+	}
+	if hss.Admission.RequestLimitMiB*1024*1024 < uint64(maxRequestBodySize) {
+		return fmt.Errorf("admission request_limit_mib (%d MiB) must not be lower than max_request_body_size (%d bytes)",
+			hss.Admission.RequestLimitMiB, maxRequestBodySize)
+	}
+	return nil
+}
+
 // ToListener creates a net.Listener.
 func (hss *ServerConfig) ToListener(ctx context.Context) (net.Listener, error) {
-	listener, err := net.Listen("tcp", hss.Endpoint)
+	addr := confignet.AddrConfig{Endpoint: hss.Endpoint, Transport: confignet.TransportTypeTCP}
+	if hss.UnixSocket != nil {
+		addr.UnixSocket = *hss.UnixSocket
+	}
+	listener, err := addr.Listen(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -407,10 +511,14 @@ func WithDecoder(key string, dec func(body io.ReadCloser) (io.ReadCloser, error)
 
 // ToServer creates an http.Server from settings object.
 func (hss *ServerConfig) ToServer(_ context.Context, host component.Host, settings component.TelemetrySettings, handler http.Handler, opts ...ToServerOption) (*http.Server, error) {
+	errHandler := defaultErrorHandler
 	configinternal.WarnOnUnspecifiedHost(settings.Logger, hss.Endpoint)
 
 	serverOpts := &toServerOptions{}
 	serverOpts.Apply(opts...)
+	if serverOpts.ErrHandler != nil {
+		errHandler = serverOpts.ErrHandler
+	}
 
 	if hss.MaxRequestBodySize <= 0 {
 		hss.MaxRequestBodySize = defaultMaxRequestBodySize
@@ -428,8 +536,19 @@ func (hss *ServerConfig) ToServer(_ context.Context, host component.Host, settin
 		serverOpts.Decoders,
 	)
 
-	if hss.MaxRequestBodySize > 0 {
-		handler = maxRequestBodySizeInterceptor(handler, hss.MaxRequestBodySize)
+	if hss.Admission != nil && hss.Admission.RequestLimitMiB > 0 {
+		// nolint:gosec
+		queue, err := admission.NewBoundedQueue(
+			int64(hss.Admission.RequestLimitMiB*1024*1024),
+			int64(hss.Admission.WaitingLimitMiB*1024*1024),
+			settings.MeterProvider,
+			"go.opentelemetry.io/collector/config/confighttp",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		handler = admissionInterceptor(handler, queue, hss.MaxRequestBodySize, errHandler)
 	}
 
 	if hss.Auth != nil {
@@ -441,6 +560,20 @@ func (hss *ServerConfig) ToServer(_ context.Context, host component.Host, settin
 		handler = authInterceptor(handler, server, hss.Auth.RequestParameters)
 	}
 
+	// The body is limited before the authenticators, which may read it to verify its signature.
+	if hss.MaxRequestBodySize > 0 {
+		handler = maxRequestBodySizeInterceptor(handler, hss.MaxRequestBodySize)
+	}
+
+	if hss.MemoryLimiter != nil {
+		limiter, err := getMemoryLimiter(host.GetExtensions(), *hss.MemoryLimiter)
+		if err != nil {
+			return nil, err
+		}
+
+		handler = memoryLimiterInterceptor(handler, limiter, hss.MemoryLimiterBudget, errHandler)
+	}
+
 	if hss.CORS != nil && len(hss.CORS.AllowedOrigins) > 0 {
 		co := cors.Options{
 			AllowedOrigins:   hss.CORS.AllowedOrigins,
@@ -530,6 +663,17 @@ func NewDefaultCORSConfig() *CORSConfig {
 }
 
 func authInterceptor(next http.Handler, server auth.Server, requestParams []string) http.Handler {
+	if httpServer, ok := server.(auth.HTTPServer); ok {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, err := httpServer.AuthenticateHTTP(r.Context(), r)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		sources := r.Header
 		query := r.URL.Query()
@@ -548,6 +692,53 @@ func authInterceptor(next http.Handler, server auth.Server, requestParams []stri
 	})
 }
 
+// getMemoryLimiter selects the memory limiter extension with the given id from the list of extensions.
+func getMemoryLimiter(extensions map[component.ID]component.Component, id component.ID) (memoryLimiter, error) {
+	if ext, found := extensions[id]; found {
+		if limiter, ok := ext.(memoryLimiter); ok {
+			return limiter, nil
+		}
+		return nil, errNotMemoryLimiter
+	}
+	return nil, fmt.Errorf("failed to resolve memory limiter %q: %w", id, errMemoryLimiterNotFound)
+}
+
+// memoryLimiterInterceptor rejects the requests while the memory limiter refuses the data of
+// the budget, before their body is read.
+func memoryLimiterInterceptor(next http.Handler, limiter memoryLimiter, budget string, errHandler func(w http.ResponseWriter, r *http.Request, errorMsg string, statusCode int)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if limiter.MustRefuseBudget(budget) {
+			errHandler(w, r, "data refused due to high memory usage", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// admissionInterceptor processes the requests once admitted by the queue, before their body is read.
+func admissionInterceptor(next http.Handler, queue *admission.BoundedQueue, maxRequestBodySize int64, errHandler func(w http.ResponseWriter, r *http.Request, errorMsg string, statusCode int)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		size := r.ContentLength
+		if size < 0 {
+			size = maxRequestBodySize
+		}
+		release, err := queue.Acquire(r.Context(), size)
+		switch {
+		case errors.Is(err, admission.ErrRequestTooLarge):
+			errHandler(w, r, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		case errors.Is(err, admission.ErrTooMuchWaiting):
+			errHandler(w, r, err.Error(), http.StatusTooManyRequests)
+			return
+		case err != nil:
+			errHandler(w, r, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		defer release()
+		next.ServeHTTP(w, r)
+	})
+}
+
 func maxRequestBodySizeInterceptor(next http.Handler, maxRecvSize int64) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		r.Body = http.MaxBytesReader(w, r.Body, maxRecvSize)
@@ -13,7 +13,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"testing"
@@ -29,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/config/configtls"
@@ -1220,6 +1223,343 @@ func TestFailedServerAuth(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("%v %s", http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized)), response.Result().Status)
 }
 
+type mockHTTPServerAuth struct {
+	auth.Server
+	authenticate func(ctx context.Context, r *http.Request) (context.Context, error)
+}
+
+func (m *mockHTTPServerAuth) AuthenticateHTTP(ctx context.Context, r *http.Request) (context.Context, error) {
+	return m.authenticate(ctx, r)
+}
+
+func TestServerHTTPAuth(t *testing.T) {
+	hss := ServerConfig{
+		Endpoint: "localhost:0",
+		Auth: &AuthConfig{
+			Authentication: configauth.Authentication{
+				AuthenticatorID: mockID,
+			},
+		},
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			mockID: &mockHTTPServerAuth{
+				Server: auth.NewServer(
+					auth.WithServerAuthenticate(func(context.Context, map[string][]string) (context.Context, error) {
+						return nil, errors.New("headers must not be authenticated")
+					}),
+				),
+				authenticate: func(ctx context.Context, r *http.Request) (context.Context, error) {
+					if r.Method != http.MethodPost || r.URL.Path != "/v1/traces" {
+						return ctx, errors.New("unexpected request")
+					}
+					r.Body = io.NopCloser(strings.NewReader("replaced"))
+					return ctx, nil
+				},
+			},
+		},
+	}
+
+	var body string
+	srv, err := hss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			b, errRead := io.ReadAll(r.Body)
+			assert.NoError(t, errRead)
+			body = string(b)
+		}))
+	require.NoError(t, err)
+
+	response := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/v1/traces", strings.NewReader("data")))
+	assert.Equal(t, http.StatusOK, response.Result().StatusCode)
+	assert.Equal(t, "replaced", body)
+
+	response = httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusUnauthorized, response.Result().StatusCode)
+}
+
+func TestServerHTTPAuthMaxRequestBodySize(t *testing.T) {
+	hss := ServerConfig{
+		Endpoint:           "localhost:0",
+		MaxRequestBodySize: 4,
+		Auth: &AuthConfig{
+			Authentication: configauth.Authentication{
+				AuthenticatorID: mockID,
+			},
+		},
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			mockID: &mockHTTPServerAuth{
+				Server: auth.NewServer(),
+				authenticate: func(ctx context.Context, r *http.Request) (context.Context, error) {
+					// The authenticators read the body limited by max_request_body_size.
+					_, err := io.ReadAll(r.Body)
+					return ctx, err
+				},
+			},
+		},
+	}
+	srv, err := hss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	require.NoError(t, err)
+
+	response := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/v1/traces", strings.NewReader("data")))
+	assert.Equal(t, http.StatusOK, response.Result().StatusCode)
+
+	response = httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/v1/traces", strings.NewReader("too long")))
+	assert.Equal(t, http.StatusUnauthorized, response.Result().StatusCode)
+}
+
+type mockMemoryLimiter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	mustRefuse bool
+	budget     string
+}
+
+func (ml *mockMemoryLimiter) MustRefuseBudget(name string) bool {
+	return ml.mustRefuse && name == ml.budget
+}
+
+func TestServerMemoryLimiter(t *testing.T) {
+	limiter := &mockMemoryLimiter{budget: "logs"}
+	hss := ServerConfig{
+		Endpoint:            "localhost:0",
+		MemoryLimiter:       &mockID,
+		MemoryLimiterBudget: "logs",
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			mockID: limiter,
+		},
+	}
+
+	handlerCalled := false
+	handler := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		handlerCalled = true
+	})
+
+	srv, err := hss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings(), handler)
+	require.NoError(t, err)
+
+	response := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("data")))
+	assert.Equal(t, http.StatusOK, response.Result().StatusCode)
+	assert.True(t, handlerCalled)
+
+	handlerCalled = false
+	limiter.mustRefuse = true
+	response = httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("data")))
+	assert.Equal(t, http.StatusServiceUnavailable, response.Result().StatusCode)
+	assert.False(t, handlerCalled)
+}
+
+func TestInvalidServerMemoryLimiter(t *testing.T) {
+	hss := ServerConfig{
+		MemoryLimiter: &nonExistingID,
+	}
+	srv, err := hss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+	require.ErrorIs(t, err, errMemoryLimiterNotFound)
+	require.Nil(t, srv)
+
+	hss.MemoryLimiter = &mockID
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			mockID: auth.NewServer(),
+		},
+	}
+	srv, err = hss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+	require.ErrorIs(t, err, errNotMemoryLimiter)
+	require.Nil(t, srv)
+}
+
+func TestServerAdmission(t *testing.T) {
+	hss := ServerConfig{
+		Endpoint:           "localhost:0",
+		MaxRequestBodySize: 2 * 1024 * 1024,
+		Admission: &AdmissionConfig{
+			RequestLimitMiB: 1,
+		},
+	}
+
+	processing := make(chan struct{})
+	done := make(chan struct{})
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		if r.ContentLength == 1024*1024 {
+			processing <- struct{}{}
+			<-done
+		}
+	})
+	srv, err := hss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), handler)
+	require.NoError(t, err)
+
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		response := httptest.NewRecorder()
+		srv.Handler.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(make([]byte, 1024*1024))))
+		assert.Equal(t, http.StatusOK, response.Result().StatusCode)
+	}()
+	<-processing
+
+	// No request can wait while the first one is processed.
+	response := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("data")))
+	assert.Equal(t, http.StatusTooManyRequests, response.Result().StatusCode)
+
+	// Requests of unknown size are sized by the max request body size.
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("data"))
+	req.ContentLength = -1
+	response = httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.Result().StatusCode)
+
+	close(done)
+	<-served
+	response = httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("data")))
+	assert.Equal(t, http.StatusOK, response.Result().StatusCode)
+}
+
+func TestServerAdmissionUnknownSize(t *testing.T) {
+	hss := ServerConfig{
+		Endpoint:           "localhost:0",
+		MaxRequestBodySize: 1024 * 1024,
+		Admission: &AdmissionConfig{
+			RequestLimitMiB: 1,
+		},
+	}
+	require.NoError(t, hss.Validate())
+	srv, err := hss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	require.NoError(t, err)
+
+	// Chunked requests are admitted under a small limit.
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("data"))
+	req.ContentLength = -1
+	req.TransferEncoding = []string{"chunked"}
+	response := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, req)
+	assert.Equal(t, http.StatusOK, response.Result().StatusCode)
+}
+
+func TestServerValidateAdmission(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ServerConfig
+		wantErr string
+	}{
+		{
+			name: "no admission",
+			cfg:  ServerConfig{},
+		},
+		{
+			name: "disabled admission",
+			cfg:  ServerConfig{Admission: &AdmissionConfig{}},
+		},
+		{
+			name: "limit equal to the max request body size",
+			cfg:  ServerConfig{MaxRequestBodySize: 1024 * 1024, Admission: &AdmissionConfig{RequestLimitMiB: 1}},
+		},
+		{
+			name:    "limit lower than the max request body size",
+			cfg:     ServerConfig{MaxRequestBodySize: 2 * 1024 * 1024, Admission: &AdmissionConfig{RequestLimitMiB: 1}},
+			wantErr: "admission request_limit_mib (1 MiB) must not be lower than max_request_body_size (2097152 bytes)",
+		},
+		{
+			name:    "limit lower than the default max request body size",
+			cfg:     ServerConfig{Admission: &AdmissionConfig{RequestLimitMiB: 4}},
+			wantErr: "admission request_limit_mib (4 MiB) must not be lower than max_request_body_size (20971520 bytes)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not supported on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "otelcol.sock")
+	hss := ServerConfig{
+		Endpoint:   "unix://" + path,
+		UnixSocket: &confignet.UnixSocketConfig{Permissions: "0600"},
+	}
+	ln, err := hss.ToListener(context.Background())
+	require.NoError(t, err)
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+
+	srv, err := hss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprint(w, r.URL.Path)
+		}))
+	require.NoError(t, err)
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		_ = srv.Serve(ln)
+	}()
+
+	hcs := ClientConfig{Endpoint: "unix://" + path}
+	client, err := hcs.ToClient(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	resp, err := client.Get("http://localhost/v1/traces")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "/v1/traces", string(body))
+
+	client.CloseIdleConnections()
+	require.NoError(t, srv.Close())
+	<-served
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestUnixSocketClientErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		hcs     ClientConfig
+		wantErr string
+	}{
+		{
+			name:    "proxy_url",
+			hcs:     ClientConfig{Endpoint: "unix:///var/run/otelcol.sock", ProxyURL: "http://proxy.example.com:8080"},
+			wantErr: "proxy_url cannot be used with a unix endpoint",
+		},
+		{
+			name: "load_balancing",
+			hcs: ClientConfig{
+				Endpoint:      "unix:///var/run/otelcol.sock",
+				LoadBalancing: &LoadBalancingConfig{Endpoints: []string{"localhost:4318"}},
+			},
+			wantErr: "load_balancing cannot be used with a unix endpoint",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.hcs.ToClient(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
 func TestServerWithErrorHandler(t *testing.T) {
 	// prepare
 	hss := ServerConfig{
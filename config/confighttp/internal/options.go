@@ -4,6 +4,7 @@
 package internal // import "go.opentelemetry.io/collector/config/confighttp/internal"
 
 import (
+	"crypto/tls"
 	"io"
 	"net/http"
 
@@ -36,3 +37,30 @@ type ToServerOptionFunc func(*ToServerOptions)
 func (of ToServerOptionFunc) apply(e *ToServerOptions) {
 	of(e)
 }
+
+// ToClientOptions has options that change the behavior of the HTTP client
+// returned by ClientConfig.ToClient().
+type ToClientOptions struct {
+	// Transport replaces the HTTP/1.1 and HTTP/2 transport with the one it returns
+	// for the loaded TLS configuration, which is nil without TLS.
+	Transport func(tlsCfg *tls.Config) (http.RoundTripper, error)
+}
+
+func (tco *ToClientOptions) Apply(opts ...ToClientOption) {
+	for _, o := range opts {
+		o.apply(tco)
+	}
+}
+
+// ToClientOption is an option to change the behavior of the HTTP client
+// returned by ClientConfig.ToClient().
+type ToClientOption interface {
+	apply(*ToClientOptions)
+}
+
+// ToClientOptionFunc converts a function into ToClientOption interface.
+type ToClientOptionFunc func(*ToClientOptions)
+
+func (of ToClientOptionFunc) apply(e *ToClientOptions) {
+	of(e)
+}
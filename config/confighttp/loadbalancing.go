@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config/internal/endpoints"
+)
+
+var errNoAddress = errors.New("no address resolved for the load balancing endpoints")
+
+// LoadBalancingConfig configures the balancing of the requests between several endpoints.
+// The requests failing without response or with the 502, 503 or 504 status code count as
+// failures for the ejection.
+type LoadBalancingConfig = endpoints.LoadBalancingConfig
+
+// EjectionConfig configures the ejection of the addresses whose requests fail repeatedly.
+type EjectionConfig = endpoints.EjectionConfig
+
+// balancingRoundTripper sends each request to one of the addresses of the endpoints,
+// picked according to the policy, skipping the ejected addresses.
+type balancingRoundTripper struct {
+	transport        *http.Transport
+	endpoints        []string
+	lookup           endpoints.LookupHostFunc
+	refreshInterval  time.Duration
+	policy           string
+	ejectionFailures int
+	ejectionDuration time.Duration
+	logger           *zap.Logger
+
+	// resolveMu is held by the request resolving the endpoints.
+	resolveMu sync.Mutex
+
+	mu         sync.Mutex
+	addrs      []*balancedAddr
+	next       int
+	resolvedAt time.Time
+	// transports are the clones of transport verifying the certificates of the addresses
+	// resolved by DNS against the host of their endpoint.
+	transports map[string]*http.Transport
+}
+
+type balancedAddr struct {
+	endpoints.Address
+	outstanding int
+	health      endpoints.Health
+}
+
+func newBalancingRoundTripper(transport *http.Transport, cfg *LoadBalancingConfig, logger *zap.Logger) *balancingRoundTripper {
+	b := &balancingRoundTripper{
+		transport:  transport,
+		endpoints:  cfg.Endpoints,
+		policy:     cfg.Policy,
+		logger:     logger,
+		transports: map[string]*http.Transport{},
+	}
+	if cfg.Resolver == endpoints.ResolverDNS {
+		b.lookup = net.DefaultResolver.LookupHost
+		b.refreshInterval = cfg.RefreshIntervalOrDefault()
+	}
+	b.ejectionFailures, b.ejectionDuration = cfg.EjectionOrDefault()
+	return b
+}
+
+// RoundTrip sends the request to the picked address, keeping the host of its URL as its Host header.
+func (b *balancingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	b.refresh(req)
+	addr, transport := b.pick()
+	if addr == nil {
+		return nil, errNoAddress
+	}
+
+	balancedReq := req.Clone(req.Context())
+	if balancedReq.Host == "" {
+		balancedReq.Host = req.URL.Host
+	}
+	balancedReq.URL.Host = addr.Addr
+	resp, err := transport.RoundTrip(balancedReq)
+	b.done(addr, err != nil || isUnhealthyStatus(resp.StatusCode))
+	return resp, err
+}
+
+// refresh resolves the endpoints when they were never resolved, or when their
+// resolution is older than the refresh interval with the "dns" resolver. Until
+// the endpoints are resolved once, all the requests wait for their resolution.
+// Afterwards, a single request refreshes them while the others use the previous
+// addresses.
+func (b *balancingRoundTripper) refresh(req *http.Request) {
+	initial, stale := b.staleness()
+	if !stale {
+		return
+	}
+	if initial {
+		b.resolveMu.Lock()
+	} else if !b.resolveMu.TryLock() {
+		return
+	}
+	defer b.resolveMu.Unlock()
+	if _, stale = b.staleness(); !stale {
+		return
+	}
+
+	resolvedAddrs, err := endpoints.Resolve(req.Context(), b.endpoints, b.lookup)
+	if err != nil {
+		b.logger.Warn("Failed to resolve some load balancing endpoints", zap.Error(err))
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(resolvedAddrs) == 0 {
+		// Keep the previous addresses, and resolve again with the next request.
+		return
+	}
+	b.resolvedAt = time.Now()
+	previous := make(map[string]*balancedAddr, len(b.addrs))
+	for _, addr := range b.addrs {
+		previous[addr.Addr] = addr
+	}
+	b.addrs = make([]*balancedAddr, 0, len(resolvedAddrs))
+	for _, resolvedAddr := range resolvedAddrs {
+		addr, ok := previous[resolvedAddr.Addr]
+		if !ok {
+			addr = &balancedAddr{Address: resolvedAddr}
+		}
+		b.addrs = append(b.addrs, addr)
+	}
+}
+
+// staleness returns whether the endpoints were never resolved, and whether they must be resolved.
+func (b *balancingRoundTripper) staleness() (initial, stale bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	initial = b.resolvedAt.IsZero()
+	return initial, initial || (b.lookup != nil && time.Since(b.resolvedAt) >= b.refreshInterval)
+}
+
+func (b *balancingRoundTripper) pick() (*balancedAddr, http.RoundTripper) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := len(b.addrs)
+	if n == 0 {
+		return nil, nil
+	}
+	now := time.Now()
+	// The ejected addresses are only used when all the addresses are ejected.
+	skipEjected := false
+	for _, addr := range b.addrs {
+		if !addr.health.Ejected(now) {
+			skipEjected = true
+			break
+		}
+	}
+
+	start := b.next % n
+	b.next = start + 1
+	var chosen *balancedAddr
+	for i := 0; i < n; i++ {
+		addr := b.addrs[(start+i)%n]
+		if skipEjected && addr.health.Ejected(now) {
+			continue
+		}
+		if b.policy != endpoints.PolicyLeastOutstanding {
+			chosen = addr
+			break
+		}
+		if chosen == nil || addr.outstanding < chosen.outstanding {
+			chosen = addr
+		}
+	}
+	chosen.outstanding++
+	return chosen, b.transportLocked(chosen)
+}
+
+// transportLocked returns the transport to send requests to the address.
+func (b *balancingRoundTripper) transportLocked(addr *balancedAddr) http.RoundTripper {
+	tlsCfg := b.transport.TLSClientConfig
+	if b.lookup == nil || tlsCfg == nil || tlsCfg.ServerName != "" {
+		return b.transport
+	}
+	transport, ok := b.transports[addr.Host]
+	if !ok {
+		transport = b.transport.Clone()
+		transport.TLSClientConfig.ServerName = addr.Host
+		b.transports[addr.Host] = transport
+	}
+	return transport
+}
+
+func (b *balancingRoundTripper) done(addr *balancedAddr, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	addr.outstanding--
+	addr.health.Record(failed, b.ejectionFailures, b.ejectionDuration, time.Now())
+}
+
+// isUnhealthyStatus returns whether a response status code reports the server as unhealthy.
+func isUnhealthyStatus(code int) bool {
+	switch code {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+// countingServer counts its requests, failing them with 503 while failing is set,
+// and blocking them until unblocked while blocking is set.
+type countingServer struct {
+	*httptest.Server
+	requests  atomic.Int64
+	failing   atomic.Bool
+	blocking  atomic.Bool
+	unblocked chan struct{}
+	host      atomic.Value
+}
+
+func newCountingServer(t *testing.T) *countingServer {
+	s := &countingServer{unblocked: make(chan struct{})}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		s.host.Store(r.Host)
+		if s.blocking.Load() {
+			<-s.unblocked
+		}
+		if s.failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *countingServer) endpoint() string {
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+func newLoadBalancingTestClient(t *testing.T, lb *LoadBalancingConfig) *http.Client {
+	hcs := ClientConfig{
+		Endpoint:      "http://otlp.example.com:4318/v1/traces",
+		LoadBalancing: lb,
+	}
+	require.NoError(t, lb.Validate())
+	client, err := hcs.ToClient(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(client.CloseIdleConnections)
+	return client
+}
+
+func sendLoadBalancedRequest(t *testing.T, client *http.Client) int {
+	req, err := http.NewRequest(http.MethodPost, "http://otlp.example.com:4318/v1/traces", http.NoBody)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp.StatusCode
+}
+
+func TestLoadBalancingRoundRobin(t *testing.T) {
+	servers := []*countingServer{newCountingServer(t), newCountingServer(t)}
+	client := newLoadBalancingTestClient(t, &LoadBalancingConfig{
+		Endpoints: []string{servers[0].endpoint(), servers[1].endpoint()},
+	})
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, http.StatusOK, sendLoadBalancedRequest(t, client))
+	}
+	assert.Equal(t, int64(5), servers[0].requests.Load())
+	assert.Equal(t, int64(5), servers[1].requests.Load())
+	// The requests keep the host of their URL.
+	assert.Equal(t, "otlp.example.com:4318", servers[0].host.Load())
+	assert.Equal(t, "otlp.example.com:4318", servers[1].host.Load())
+}
+
+func TestLoadBalancingLeastOutstanding(t *testing.T) {
+	servers := []*countingServer{newCountingServer(t), newCountingServer(t)}
+	client := newLoadBalancingTestClient(t, &LoadBalancingConfig{
+		Endpoints: []string{servers[0].endpoint(), servers[1].endpoint()},
+		Policy:    "least_outstanding",
+	})
+	servers[0].blocking.Store(true)
+
+	// Keep a request waiting for its response from the first server.
+	responded := make(chan int)
+	for i := 0; i < 2; i++ {
+		go func() {
+			responded <- sendLoadBalancedRequest(t, client)
+		}()
+	}
+	require.Eventually(t, func() bool {
+		return servers[0].requests.Load() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, http.StatusOK, <-responded)
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, sendLoadBalancedRequest(t, client))
+	}
+	assert.Equal(t, int64(1), servers[0].requests.Load())
+	assert.Equal(t, int64(6), servers[1].requests.Load())
+
+	close(servers[0].unblocked)
+	assert.Equal(t, http.StatusOK, <-responded)
+}
+
+func TestLoadBalancingEjection(t *testing.T) {
+	servers := []*countingServer{newCountingServer(t), newCountingServer(t)}
+	client := newLoadBalancingTestClient(t, &LoadBalancingConfig{
+		Endpoints: []string{servers[0].endpoint(), servers[1].endpoint()},
+		Ejection:  &EjectionConfig{ConsecutiveFailures: 2, Duration: time.Hour},
+	})
+	servers[0].failing.Store(true)
+
+	var failures int
+	for i := 0; i < 10; i++ {
+		if sendLoadBalancedRequest(t, client) != http.StatusOK {
+			failures++
+		}
+	}
+	// The failing server is ejected after 2 failed requests.
+	assert.Equal(t, 2, failures)
+	assert.Equal(t, int64(2), servers[0].requests.Load())
+	assert.Equal(t, int64(8), servers[1].requests.Load())
+
+	// All the addresses are used when all of them are ejected.
+	servers[1].failing.Store(true)
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusServiceUnavailable, sendLoadBalancedRequest(t, client))
+	}
+	servers[0].failing.Store(false)
+	servers[1].failing.Store(false)
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusOK, sendLoadBalancedRequest(t, client))
+	}
+	assert.Equal(t, int64(3), servers[0].requests.Load())
+	assert.Equal(t, int64(11), servers[1].requests.Load())
+}
+
+func TestLoadBalancingDNS(t *testing.T) {
+	server := newCountingServer(t)
+	_, port, err := net.SplitHostPort(server.endpoint())
+	require.NoError(t, err)
+	client := newLoadBalancingTestClient(t, &LoadBalancingConfig{
+		Endpoints:       []string{net.JoinHostPort("localhost", port)},
+		Resolver:        "dns",
+		RefreshInterval: time.Millisecond,
+		Ejection:        &EjectionConfig{},
+	})
+
+	// localhost may resolve to an IPv6 address the server doesn't listen on,
+	// which is ejected after failing.
+	var ok int
+	for i := 0; i < 20; i++ {
+		req, err := http.NewRequest(http.MethodPost, "http://otlp.example.com:4318/v1/traces", http.NoBody)
+		require.NoError(t, err)
+		if resp, err := client.Do(req); err == nil {
+			require.NoError(t, resp.Body.Close())
+			ok++
+		}
+	}
+	assert.Positive(t, ok)
+	assert.Equal(t, int64(ok), server.requests.Load())
+}
+
+func TestLoadBalancingUnresolvedEndpoints(t *testing.T) {
+	b := newBalancingRoundTripper(http.DefaultTransport.(*http.Transport), &LoadBalancingConfig{
+		Endpoints: []string{"backend:4318"},
+		Resolver:  "dns",
+	}, zap.NewNop())
+	b.lookup = func(context.Context, string) ([]string, error) {
+		return nil, errors.New("no such host")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "http://otlp.example.com:4318/v1/traces", http.NoBody)
+	require.NoError(t, err)
+	_, err = b.RoundTrip(req)
+	require.ErrorIs(t, err, errNoAddress)
+
+	// The endpoints are resolved again by the next request.
+	b.lookup = func(context.Context, string) ([]string, error) {
+		return []string{"10.0.0.1"}, nil
+	}
+	b.refresh(req)
+	addr, _ := b.pick()
+	require.NotNil(t, addr)
+	assert.Equal(t, "10.0.0.1:4318", addr.Addr)
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconfighttp // import "go.opentelemetry.io/collector/config/confighttp/xconfighttp"
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confighttp/internal"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+var errHTTP3RequiresTLS = errors.New("http3 requires TLS")
+
+// HTTP3Config configures HTTP/3 over QUIC. Components opt into HTTP/3 by adding it,
+// under `http3`, next to their confighttp.ClientConfig or confighttp.ServerConfig.
+//
+// This is located in the experimental sub-package so that the components not using
+// HTTP/3 don't depend on QUIC.
+type HTTP3Config struct {
+	// Enabled enables HTTP/3. Clients send all their requests with HTTP/3, while servers
+	// receive HTTP/3 requests on the UDP port of their endpoint alongside the HTTP/1.1 and
+	// HTTP/2 requests. HTTP/3 requires TLS.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxIdleTimeout is the maximum duration a QUIC connection may be idle before it is closed.
+	// Default is 30s.
+	MaxIdleTimeout time.Duration `mapstructure:"max_idle_timeout"`
+
+	// KeepAlivePeriod is the period of the packets sent to keep QUIC connections alive.
+	// Default is 0, which disables them.
+	KeepAlivePeriod time.Duration `mapstructure:"keep_alive_period"`
+}
+
+// Validate checks if the HTTP/3 configuration is valid.
+func (cfg *HTTP3Config) Validate() error {
+	var errs error
+	if cfg.MaxIdleTimeout < 0 {
+		errs = errors.Join(errs, errors.New("http3 max_idle_timeout must be non-negative"))
+	}
+	if cfg.KeepAlivePeriod < 0 {
+		errs = errors.Join(errs, errors.New("http3 keep_alive_period must be non-negative"))
+	}
+	return errs
+}
+
+func (cfg *HTTP3Config) quicConfig() *quic.Config {
+	return &quic.Config{
+		MaxIdleTimeout:  cfg.MaxIdleTimeout,
+		KeepAlivePeriod: cfg.KeepAlivePeriod,
+	}
+}
+
+// ToClient creates an HTTP client from hcs, as hcs.ToClient does, which sends all its
+// requests with HTTP/3. The proxy, buffer size, connection and HTTP/2 settings of hcs
+// don't apply, and it can't use load balancing. The returned io.Closer closes the QUIC
+// connections, and must be called once the client is no longer used.
+func (cfg *HTTP3Config) ToClient(ctx context.Context, hcs *confighttp.ClientConfig, host component.Host, settings component.TelemetrySettings) (*http.Client, io.Closer, error) {
+	if hcs.LoadBalancing != nil {
+		return nil, nil, errors.New("http3 cannot be used with load_balancing")
+	}
+	if _, isUnix := confignet.UnixSocketPath(hcs.Endpoint); isUnix {
+		return nil, nil, errors.New("http3 cannot be used with a unix endpoint")
+	}
+	transport := &http3.Transport{QUICConfig: cfg.quicConfig()}
+	client, err := hcs.ToClient(ctx, host, settings, internal.ToClientOptionFunc(func(opts *internal.ToClientOptions) {
+		opts.Transport = func(tlsCfg *tls.Config) (http.RoundTripper, error) {
+			if tlsCfg == nil {
+				return nil, errHTTP3RequiresTLS
+			}
+			transport.TLSClientConfig = tlsCfg
+			return transport, nil
+		}
+	}))
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, transport, nil
+}
+
+// ToQUICListener creates the QUIC listener receiving the HTTP/3 requests, on the UDP port
+// of the endpoint of hss. It requires a TLS certificate.
+func (cfg *HTTP3Config) ToQUICListener(ctx context.Context, hss *confighttp.ServerConfig) (*quic.EarlyListener, error) {
+	if hss.TLSSetting == nil || (hss.TLSSetting.CertFile == "" && hss.TLSSetting.CertPem == "") {
+		return nil, errHTTP3RequiresTLS
+	}
+	if _, isUnix := confignet.UnixSocketPath(hss.Endpoint); isUnix {
+		return nil, errors.New("http3 cannot be used with a unix endpoint")
+	}
+	tlsCfg, err := hss.TLSSetting.LoadTLSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return quic.ListenAddrEarly(hss.Endpoint, http3.ConfigureTLSConfig(tlsCfg), cfg.quicConfig())
+}
+
+// NewHTTP3Server returns an http3.Server serving the requests with the handler, and the
+// limits, of server created by confighttp.ServerConfig.ToServer. It serves the listener
+// created by ToQUICListener with ServeListener, and the listener must be closed once the
+// server is shut down.
+func NewHTTP3Server(server *http.Server) *http3.Server {
+	return &http3.Server{
+		Handler:        server.Handler,
+		MaxHeaderBytes: server.MaxHeaderBytes,
+		IdleTimeout:    server.IdleTimeout,
+	}
+}
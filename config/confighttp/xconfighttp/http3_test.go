@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconfighttp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+func TestHTTP3ConfigValidate(t *testing.T) {
+	cfg := &HTTP3Config{Enabled: true, MaxIdleTimeout: time.Minute, KeepAlivePeriod: 10 * time.Second}
+	require.NoError(t, cfg.Validate())
+
+	cfg = &HTTP3Config{Enabled: true, MaxIdleTimeout: -time.Second, KeepAlivePeriod: -time.Second}
+	assert.EqualError(t, cfg.Validate(),
+		"http3 max_idle_timeout must be non-negative\nhttp3 keep_alive_period must be non-negative")
+}
+
+func TestHTTP3RoundTrip(t *testing.T) {
+	hss := &confighttp.ServerConfig{
+		Endpoint: "localhost:0",
+		TLSSetting: &configtls.ServerConfig{
+			Config: configtls.Config{
+				CertFile: filepath.Join("..", "testdata", "server.crt"),
+				KeyFile:  filepath.Join("..", "testdata", "server.key"),
+			},
+		},
+	}
+	http3Cfg := &HTTP3Config{Enabled: true, MaxIdleTimeout: time.Minute}
+	ln, err := http3Cfg.ToQUICListener(context.Background(), hss)
+	require.NoError(t, err)
+	s, err := hss.ToServer(
+		context.Background(),
+		componenttest.NewNopHost(),
+		componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, errWrite := fmt.Fprint(w, r.Proto)
+			assert.NoError(t, errWrite)
+		}))
+	require.NoError(t, err)
+	s3 := NewHTTP3Server(s)
+	served := make(chan error)
+	go func() {
+		served <- s3.ServeListener(ln)
+	}()
+
+	hcs := &confighttp.ClientConfig{
+		TLSSetting: configtls.ClientConfig{
+			Config: configtls.Config{
+				CAFile: filepath.Join("..", "testdata", "ca.crt"),
+			},
+			ServerName: "localhost",
+		},
+	}
+	client, closer, err := http3Cfg.ToClient(context.Background(), hcs, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	resp, err := client.Get("https://" + ln.Addr().String())
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "HTTP/3.0", string(body))
+
+	require.NoError(t, closer.Close())
+	require.NoError(t, s3.Close())
+	assert.ErrorIs(t, <-served, http.ErrServerClosed)
+	require.NoError(t, ln.Close())
+}
+
+func TestHTTP3ClientErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		hcs     confighttp.ClientConfig
+		wantErr string
+	}{
+		{
+			name:    "insecure",
+			hcs:     confighttp.ClientConfig{TLSSetting: configtls.ClientConfig{Insecure: true}},
+			wantErr: "http3 requires TLS",
+		},
+		{
+			name: "load_balancing",
+			hcs: confighttp.ClientConfig{
+				LoadBalancing: &confighttp.LoadBalancingConfig{Endpoints: []string{"backend:4318"}},
+			},
+			wantErr: "http3 cannot be used with load_balancing",
+		},
+		{
+			name:    "unix",
+			hcs:     confighttp.ClientConfig{Endpoint: "unix:///var/run/otelcol.sock"},
+			wantErr: "http3 cannot be used with a unix endpoint",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &HTTP3Config{Enabled: true}
+			_, _, err := cfg.ToClient(context.Background(), &tt.hcs, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestHTTP3ServerRequiresTLS(t *testing.T) {
+	cfg := &HTTP3Config{Enabled: true}
+	_, err := cfg.ToQUICListener(context.Background(), &confighttp.ServerConfig{Endpoint: "localhost:0"})
+	require.ErrorIs(t, err, errHTTP3RequiresTLS)
+}
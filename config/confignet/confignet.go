@@ -74,6 +74,8 @@ type AddrConfig struct {
 	// or a host name that can be resolved to IP addresses. The port must be a literal port number or a service name.
 	// If the host is a literal IPv6 address it must be enclosed in square brackets, as in "[2001:db8::1]:80" or
 	// "[fe80::1%zone]:80". The zone specifies the scope of the literal IPv6 address as defined in RFC 4007.
+	// For Unix networks, the address is the path of the socket. An endpoint of the form "unix:///path/to/socket"
+	// uses the Unix domain socket at this path, whatever the transport.
 	Endpoint string `mapstructure:"endpoint"`
 
 	// Transport to use. Allowed protocols are "tcp", "tcp4" (IPv4-only), "tcp6" (IPv6-only), "udp", "udp4" (IPv4-only),
@@ -82,6 +84,9 @@ type AddrConfig struct {
 
 	// DialerConfig contains options for connecting to an address.
 	DialerConfig DialerConfig `mapstructure:"dialer"`
+
+	// UnixSocket configures the socket file created when listening on a Unix domain socket.
+	UnixSocket UnixSocketConfig `mapstructure:"unix_socket"`
 }
 
 // NewDefaultAddrConfig creates a new AddrConfig with any default values set
@@ -94,13 +99,28 @@ func NewDefaultAddrConfig() AddrConfig {
 // Dial equivalent with net.Dialer's DialContext for this address.
 func (na *AddrConfig) Dial(ctx context.Context) (net.Conn, error) {
 	d := net.Dialer{Timeout: na.DialerConfig.Timeout}
-	return d.DialContext(ctx, string(na.Transport), na.Endpoint)
+	network, address := na.networkAddress()
+	return d.DialContext(ctx, network, address)
 }
 
-// Listen equivalent with net.ListenConfig's Listen for this address.
+// Listen equivalent with net.ListenConfig's Listen for this address. When listening on
+// a Unix domain socket, a socket file left behind is replaced if no server accepts
+// connections on it, and the permissions of the socket file are set from UnixSocket.
 func (na *AddrConfig) Listen(ctx context.Context) (net.Listener, error) {
+	network, address := na.networkAddress()
+	switch TransportType(network) {
+	case TransportTypeUnix, TransportTypeUnixPacket:
+		return listenUnix(ctx, network, address, na.UnixSocket)
+	}
 	lc := net.ListenConfig{}
-	return lc.Listen(ctx, string(na.Transport), na.Endpoint)
+	return lc.Listen(ctx, network, address)
+}
+
+func (na *AddrConfig) networkAddress() (string, string) {
+	if path, ok := UnixSocketPath(na.Endpoint); ok {
+		return string(TransportTypeUnix), path
+	}
+	return string(na.Transport), na.Endpoint
 }
 
 func (na *AddrConfig) Validate() error {
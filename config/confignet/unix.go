@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const unixScheme = "unix://"
+
+// UnixSocketPath returns the path of the Unix domain socket of a "unix://" endpoint,
+// e.g. "/var/run/otelcol.sock" for "unix:///var/run/otelcol.sock", and whether the
+// endpoint is a "unix://" endpoint.
+func UnixSocketPath(endpoint string) (string, bool) {
+	if !strings.HasPrefix(endpoint, unixScheme) {
+		return "", false
+	}
+	return strings.TrimPrefix(endpoint, unixScheme), true
+}
+
+// UnixSocketConfig configures the socket file created by a server listening on a Unix domain socket.
+type UnixSocketConfig struct {
+	// Permissions of the socket file, in octal notation, e.g. "0660". By default, the
+	// permissions of the socket file follow the umask of the process.
+	Permissions string `mapstructure:"permissions"`
+}
+
+// Validate checks if the Unix domain socket configuration is valid.
+func (cfg *UnixSocketConfig) Validate() error {
+	_, _, err := cfg.fileMode()
+	return err
+}
+
+// fileMode returns the permissions of the socket file, and whether they are set.
+func (cfg *UnixSocketConfig) fileMode() (os.FileMode, bool, error) {
+	if cfg.Permissions == "" {
+		return 0, false, nil
+	}
+	mode, err := strconv.ParseUint(cfg.Permissions, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, false, fmt.Errorf("invalid unix_socket permissions %q, must be an octal file mode such as \"0660\"", cfg.Permissions)
+	}
+	return os.FileMode(mode), true, nil
+}
+
+// listenUnix listens on the Unix domain socket at path, replacing the socket file left
+// behind by a server which didn't shut down, and sets the permissions of the socket file.
+func listenUnix(ctx context.Context, network, path string, cfg UnixSocketConfig) (net.Listener, error) {
+	if path == "" {
+		return nil, errors.New("the path of the unix socket must not be empty")
+	}
+	mode, setMode, err := cfg.fileMode()
+	if err != nil {
+		return nil, err
+	}
+	if err = removeStaleSocket(network, path); err != nil {
+		return nil, err
+	}
+	lc := net.ListenConfig{}
+	ln, err := lc.Listen(ctx, network, path)
+	if err != nil {
+		return nil, err
+	}
+	if setMode {
+		if err = os.Chmod(path, mode); err != nil {
+			return nil, errors.Join(err, ln.Close())
+		}
+	}
+	return ln, nil
+}
+
+// removeStaleSocket removes the socket file at path when no server accepts connections on it.
+func removeStaleSocket(network, path string) error {
+	fi, err := os.Stat(path)
+	if err != nil || fi.Mode()&os.ModeSocket == 0 {
+		// Listening reports the file which isn't a socket.
+		return nil
+	}
+	if conn, dialErr := net.Dial(network, path); dialErr == nil {
+		_ = conn.Close()
+		return fmt.Errorf("the unix socket %q is already in use", path)
+	}
+	return os.Remove(path)
+}
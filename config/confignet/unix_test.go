@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnixSocketPath(t *testing.T) {
+	path, ok := UnixSocketPath("unix:///var/run/otelcol.sock")
+	assert.True(t, ok)
+	assert.Equal(t, "/var/run/otelcol.sock", path)
+
+	_, ok = UnixSocketPath("localhost:4317")
+	assert.False(t, ok)
+	_, ok = UnixSocketPath("/var/run/otelcol.sock")
+	assert.False(t, ok)
+}
+
+func TestUnixSocketConfigValidate(t *testing.T) {
+	tests := []struct {
+		permissions string
+		wantErr     bool
+	}{
+		{permissions: ""},
+		{permissions: "0660"},
+		{permissions: "600"},
+		{permissions: "0000"},
+		{permissions: "0o660", wantErr: true},
+		{permissions: "0999", wantErr: true},
+		{permissions: "01777", wantErr: true},
+		{permissions: "rw-rw----", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.permissions, func(t *testing.T) {
+			cfg := UnixSocketConfig{Permissions: tt.permissions}
+			err := cfg.Validate()
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "invalid unix_socket permissions")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAddrConfigUnixEndpoint(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not supported on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "otelcol.sock")
+	nac := &AddrConfig{
+		Endpoint:   "unix://" + path,
+		Transport:  TransportTypeTCP,
+		UnixSocket: UnixSocketConfig{Permissions: "0600"},
+	}
+	ln, err := nac.Listen(context.Background())
+	require.NoError(t, err)
+
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+	assert.NotZero(t, fi.Mode()&os.ModeSocket)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, errAccept := ln.Accept()
+		if !assert.NoError(t, errAccept) {
+			return
+		}
+		buf := make([]byte, 4)
+		_, errRead := conn.Read(buf)
+		assert.NoError(t, errRead)
+		assert.Equal(t, "ping", string(buf))
+		assert.NoError(t, conn.Close())
+	}()
+
+	conn, err := nac.Dial(context.Background())
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+	<-done
+	require.NoError(t, conn.Close())
+
+	// A second server can't listen on the socket in use.
+	_, err = nac.Listen(context.Background())
+	require.ErrorContains(t, err, "already in use")
+
+	require.NoError(t, ln.Close())
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestAddrConfigUnixStaleSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stale socket files are not left behind on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "otelcol.sock")
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	// Closing the listener without removing the socket file, as after a crash.
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+
+	nac := &AddrConfig{Endpoint: path, Transport: TransportTypeUnix}
+	ln, err := nac.Listen(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, ln.Close())
+}
+
+func TestAddrConfigUnixEmptyPath(t *testing.T) {
+	nac := &AddrConfig{Endpoint: "unix://", Transport: TransportTypeTCP}
+	_, err := nac.Listen(context.Background())
+	require.ErrorContains(t, err, "must not be empty")
+}
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package admission implements the admission control of server requests by their
+// size in bytes.
+package admission // import "go.opentelemetry.io/collector/config/internal/admission"
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	// ErrRequestTooLarge is returned for requests larger than the limit of in-flight bytes,
+	// which can never be admitted.
+	ErrRequestTooLarge = errors.New("request is larger than the limit of in-flight bytes")
+
+	// ErrTooMuchWaiting is returned for requests which would make the waiting bytes exceed
+	// their limit.
+	ErrTooMuchWaiting = errors.New("too many request bytes are waiting to be admitted")
+)
+
+// BoundedQueue admits requests as long as the bytes of the requests in flight stay
+// below a limit. Other requests wait to be admitted, in order, as long as the bytes
+// of the waiting requests stay below another limit, and are rejected otherwise.
+type BoundedQueue struct {
+	requestLimit int64
+	waitingLimit int64
+
+	mu       sync.Mutex
+	inFlight int64
+	waiting  int64
+	waiters  *list.List
+
+	inFlightBytes    metric.Int64UpDownCounter
+	waitingBytes     metric.Int64UpDownCounter
+	rejectedRequests metric.Int64Counter
+}
+
+type waiter struct {
+	bytes int64
+	// admitted is closed once the waiter is admitted.
+	admitted chan struct{}
+}
+
+// NewBoundedQueue returns a BoundedQueue with the given limits of in-flight and waiting bytes,
+// reporting its metrics with meters of the given scope.
+func NewBoundedQueue(requestLimit, waitingLimit int64, mp metric.MeterProvider, scope string) (*BoundedQueue, error) {
+	meter := mp.Meter(scope)
+	q := &BoundedQueue{
+		requestLimit: requestLimit,
+		waitingLimit: waitingLimit,
+		waiters:      list.New(),
+	}
+	var errs, err error
+	q.inFlightBytes, err = meter.Int64UpDownCounter(
+		"otelcol_server_admission_in_flight_bytes",
+		metric.WithDescription("Bytes of the requests admitted and being processed by the server."),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	q.waitingBytes, err = meter.Int64UpDownCounter(
+		"otelcol_server_admission_waiting_bytes",
+		metric.WithDescription("Bytes of the requests waiting to be admitted by the server."),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	q.rejectedRequests, err = meter.Int64Counter(
+		"otelcol_server_admission_rejected_requests",
+		metric.WithDescription("Number of requests rejected by the server because of the admission limits."),
+		metric.WithUnit("{requests}"),
+	)
+	errs = errors.Join(errs, err)
+	return q, errs
+}
+
+// Acquire admits a request of the given size, waiting until it can be admitted or ctx is done.
+// Once admitted, the returned function must be called after the request was processed.
+func (q *BoundedQueue) Acquire(ctx context.Context, bytes int64) (func(), error) {
+	if bytes > q.requestLimit {
+		q.rejectedRequests.Add(ctx, 1)
+		return nil, ErrRequestTooLarge
+	}
+
+	q.mu.Lock()
+	if q.waiters.Len() == 0 && q.inFlight+bytes <= q.requestLimit {
+		q.inFlight += bytes
+		q.inFlightBytes.Add(ctx, bytes)
+		q.mu.Unlock()
+		return q.releaseFunc(bytes), nil
+	}
+	if q.waiting+bytes > q.waitingLimit {
+		q.mu.Unlock()
+		q.rejectedRequests.Add(ctx, 1)
+		return nil, ErrTooMuchWaiting
+	}
+	w := &waiter{bytes: bytes, admitted: make(chan struct{})}
+	elem := q.waiters.PushBack(w)
+	q.waiting += bytes
+	q.waitingBytes.Add(ctx, bytes)
+	q.mu.Unlock()
+
+	select {
+	case <-w.admitted:
+		return q.releaseFunc(bytes), nil
+	case <-ctx.Done():
+	}
+
+	q.mu.Lock()
+	select {
+	case <-w.admitted:
+		// Admitted while ctx was done, give the bytes back to the other waiters.
+		q.mu.Unlock()
+		q.release(bytes)
+	default:
+		q.waiters.Remove(elem)
+		q.waiting -= bytes
+		q.waitingBytes.Add(context.Background(), -bytes)
+		// The waiters behind may fit now.
+		q.admitWaiters()
+		q.mu.Unlock()
+	}
+	return nil, ctx.Err()
+}
+
+func (q *BoundedQueue) releaseFunc(bytes int64) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() { q.release(bytes) })
+	}
+}
+
+func (q *BoundedQueue) release(bytes int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.inFlight -= bytes
+	q.inFlightBytes.Add(context.Background(), -bytes)
+	q.admitWaiters()
+}
+
+// admitWaiters admits the waiters, in order, as long as they fit in the limit of in-flight bytes.
+// It must be called with mu held.
+func (q *BoundedQueue) admitWaiters() {
+	for elem := q.waiters.Front(); elem != nil; elem = q.waiters.Front() {
+		w := elem.Value.(*waiter)
+		if q.inFlight+w.bytes > q.requestLimit {
+			return
+		}
+		q.waiters.Remove(elem)
+		q.waiting -= w.bytes
+		q.inFlight += w.bytes
+		q.waitingBytes.Add(context.Background(), -w.bytes)
+		q.inFlightBytes.Add(context.Background(), w.bytes)
+		close(w.admitted)
+	}
+}
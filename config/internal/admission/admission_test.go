@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package admission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func newTestQueue(t *testing.T, requestLimit, waitingLimit int64) (*BoundedQueue, *sdkmetric.ManualReader) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { assert.NoError(t, mp.Shutdown(context.Background())) })
+	q, err := NewBoundedQueue(requestLimit, waitingLimit, mp, "test")
+	require.NoError(t, err)
+	return q, reader
+}
+
+func metricValues(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				values[m.Name] += dp.Value
+			}
+		}
+	}
+	return values
+}
+
+func TestBoundedQueueAdmit(t *testing.T) {
+	q, reader := newTestQueue(t, 100, 50)
+
+	release1, err := q.Acquire(context.Background(), 60)
+	require.NoError(t, err)
+	release2, err := q.Acquire(context.Background(), 40)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), metricValues(t, reader)["otelcol_server_admission_in_flight_bytes"])
+
+	admitted := make(chan func())
+	go func() {
+		release, err := q.Acquire(context.Background(), 50)
+		assert.NoError(t, err)
+		admitted <- release
+	}()
+	assert.Eventually(t, func() bool {
+		return metricValues(t, reader)["otelcol_server_admission_waiting_bytes"] == 50
+	}, time.Second, time.Millisecond)
+
+	// Releasing 40 bytes is not enough to admit the waiting 50 bytes.
+	release2()
+	release2()
+	select {
+	case <-admitted:
+		t.Fatal("request admitted above the limit")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	release1()
+	release3 := <-admitted
+	values := metricValues(t, reader)
+	assert.Equal(t, int64(50), values["otelcol_server_admission_in_flight_bytes"])
+	assert.Equal(t, int64(0), values["otelcol_server_admission_waiting_bytes"])
+	release3()
+	assert.Equal(t, int64(0), metricValues(t, reader)["otelcol_server_admission_in_flight_bytes"])
+}
+
+func TestBoundedQueueReject(t *testing.T) {
+	q, reader := newTestQueue(t, 100, 50)
+
+	_, err := q.Acquire(context.Background(), 101)
+	require.ErrorIs(t, err, ErrRequestTooLarge)
+
+	release, err := q.Acquire(context.Background(), 100)
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	waited := make(chan error)
+	go func() {
+		_, err := q.Acquire(ctx, 50)
+		waited <- err
+	}()
+	assert.Eventually(t, func() bool {
+		return metricValues(t, reader)["otelcol_server_admission_waiting_bytes"] == 50
+	}, time.Second, time.Millisecond)
+
+	_, err = q.Acquire(context.Background(), 1)
+	require.ErrorIs(t, err, ErrTooMuchWaiting)
+	assert.Equal(t, int64(2), metricValues(t, reader)["otelcol_server_admission_rejected_requests"])
+
+	cancel()
+	require.ErrorIs(t, <-waited, context.Canceled)
+	assert.Equal(t, int64(0), metricValues(t, reader)["otelcol_server_admission_waiting_bytes"])
+}
+
+func TestBoundedQueueCanceledWaiterUnblocksOthers(t *testing.T) {
+	q, err := NewBoundedQueue(100, 100, noop.NewMeterProvider(), "test")
+	require.NoError(t, err)
+
+	release, err := q.Acquire(context.Background(), 60)
+	require.NoError(t, err)
+	defer release()
+
+	// A large waiter blocks a smaller one behind it until it is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	large := make(chan error)
+	go func() {
+		_, err := q.Acquire(ctx, 50)
+		large <- err
+	}()
+	assert.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		return q.waiters.Len() == 1
+	}, time.Second, time.Millisecond)
+
+	small := make(chan error)
+	go func() {
+		release, err := q.Acquire(context.Background(), 40)
+		if err == nil {
+			release()
+		}
+		small <- err
+	}()
+	assert.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		return q.waiters.Len() == 2
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-large, context.Canceled)
+	require.NoError(t, <-small)
+}
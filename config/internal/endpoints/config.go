@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package endpoints // import "go.opentelemetry.io/collector/config/internal/endpoints"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// DefaultRefreshInterval is the default interval between the resolutions of the endpoints with ResolverDNS.
+	DefaultRefreshInterval = 30 * time.Second
+	// DefaultEjectionFailures is the default number of consecutive failures after which an address is ejected.
+	DefaultEjectionFailures = 5
+	// DefaultEjectionDuration is the default duration of the ejection of an address.
+	DefaultEjectionDuration = 30 * time.Second
+)
+
+// LoadBalancingConfig configures the balancing of the requests of a client between several
+// endpoints. It is shared by the gRPC and HTTP clients.
+type LoadBalancingConfig struct {
+	// Endpoints are the host:port of the endpoints to balance the requests between.
+	// For gRPC clients, they replace the endpoint of the client. For HTTP clients, the
+	// requests are sent to them instead of the host of their URL, which is kept as their
+	// Host header.
+	Endpoints []string `mapstructure:"endpoints"`
+
+	// Resolver is how the endpoints are resolved to addresses, either "static" to use
+	// each endpoint as is, or "dns" to use all the IP addresses of their host. Default is "static".
+	Resolver string `mapstructure:"resolver"`
+
+	// RefreshInterval is the interval between the resolutions of the endpoints with
+	// the "dns" resolver. Default is 30s.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+
+	// Policy is how an address is picked for each request, either "round_robin" or
+	// "least_outstanding" for the address with the least requests in progress.
+	// Default is "round_robin".
+	Policy string `mapstructure:"policy"`
+
+	// Ejection configures the ejection of the unhealthy addresses. Addresses are never
+	// ejected when unset.
+	Ejection *EjectionConfig `mapstructure:"ejection"`
+}
+
+// EjectionConfig configures the ejection of the addresses whose requests fail repeatedly.
+type EjectionConfig struct {
+	// ConsecutiveFailures is the number of consecutive failed requests after which an address
+	// is ejected. RPCs failing with UNAVAILABLE or DEADLINE_EXCEEDED, and HTTP requests failing
+	// without response or with the 502, 503 or 504 status code are failed. Default is 5.
+	ConsecutiveFailures int `mapstructure:"consecutive_failures"`
+
+	// Duration is how long an address is ejected. Default is 30s.
+	Duration time.Duration `mapstructure:"duration"`
+}
+
+// Validate checks if the load balancing configuration is valid.
+func (cfg *LoadBalancingConfig) Validate() error {
+	var errs error
+	if len(cfg.Endpoints) == 0 {
+		errs = errors.Join(errs, errors.New("load balancing requires at least one endpoint"))
+	}
+	for _, endpoint := range cfg.Endpoints {
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid load balancing endpoint %q: %w", endpoint, err))
+		}
+	}
+	switch cfg.Resolver {
+	case "", ResolverStatic, ResolverDNS:
+	default:
+		errs = errors.Join(errs, fmt.Errorf("invalid load balancing resolver: %q", cfg.Resolver))
+	}
+	switch cfg.Policy {
+	case "", PolicyRoundRobin, PolicyLeastOutstanding:
+	default:
+		errs = errors.Join(errs, fmt.Errorf("invalid load balancing policy: %q", cfg.Policy))
+	}
+	if cfg.RefreshInterval < 0 {
+		errs = errors.Join(errs, errors.New("load balancing refresh_interval must be non-negative"))
+	}
+	if cfg.Ejection != nil {
+		if cfg.Ejection.ConsecutiveFailures < 0 {
+			errs = errors.Join(errs, errors.New("ejection consecutive_failures must be non-negative"))
+		}
+		if cfg.Ejection.Duration < 0 {
+			errs = errors.Join(errs, errors.New("ejection duration must be non-negative"))
+		}
+	}
+	return errs
+}
+
+// RefreshIntervalOrDefault returns the refresh interval, or DefaultRefreshInterval if unset.
+func (cfg *LoadBalancingConfig) RefreshIntervalOrDefault() time.Duration {
+	if cfg.RefreshInterval == 0 {
+		return DefaultRefreshInterval
+	}
+	return cfg.RefreshInterval
+}
+
+// EjectionOrDefault returns the number of consecutive failures after which an address is
+// ejected and the duration of the ejection, replacing the unset values by their default.
+// A zero number of failures disables ejection.
+func (cfg *LoadBalancingConfig) EjectionOrDefault() (int, time.Duration) {
+	if cfg.Ejection == nil {
+		return 0, 0
+	}
+	failures, duration := cfg.Ejection.ConsecutiveFailures, cfg.Ejection.Duration
+	if failures == 0 {
+		failures = DefaultEjectionFailures
+	}
+	if duration == 0 {
+		duration = DefaultEjectionDuration
+	}
+	return failures, duration
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package endpoints
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadBalancingConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  LoadBalancingConfig
+		err  string
+	}{
+		{
+			name: "static",
+			cfg:  LoadBalancingConfig{Endpoints: []string{"backend-1:4317", "backend-2:4317"}},
+		},
+		{
+			name: "dns",
+			cfg: LoadBalancingConfig{
+				Endpoints:       []string{"backend:4317"},
+				Resolver:        "dns",
+				RefreshInterval: time.Minute,
+				Policy:          "least_outstanding",
+				Ejection:        &EjectionConfig{ConsecutiveFailures: 3, Duration: time.Minute},
+			},
+		},
+		{
+			name: "no endpoint",
+			err:  "load balancing requires at least one endpoint",
+		},
+		{
+			name: "missing port",
+			cfg:  LoadBalancingConfig{Endpoints: []string{"backend"}},
+			err:  `invalid load balancing endpoint "backend"`,
+		},
+		{
+			name: "invalid resolver",
+			cfg:  LoadBalancingConfig{Endpoints: []string{"backend:4317"}, Resolver: "consul"},
+			err:  `invalid load balancing resolver: "consul"`,
+		},
+		{
+			name: "invalid policy",
+			cfg:  LoadBalancingConfig{Endpoints: []string{"backend:4317"}, Policy: "random"},
+			err:  `invalid load balancing policy: "random"`,
+		},
+		{
+			name: "negative refresh interval",
+			cfg:  LoadBalancingConfig{Endpoints: []string{"backend:4317"}, RefreshInterval: -time.Second},
+			err:  "load balancing refresh_interval must be non-negative",
+		},
+		{
+			name: "negative ejection",
+			cfg: LoadBalancingConfig{
+				Endpoints: []string{"backend:4317"},
+				Ejection:  &EjectionConfig{ConsecutiveFailures: -1, Duration: -time.Second},
+			},
+			err: "ejection consecutive_failures must be non-negative\nejection duration must be non-negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestLoadBalancingConfigDefaults(t *testing.T) {
+	cfg := LoadBalancingConfig{Endpoints: []string{"backend:4317"}}
+	assert.Equal(t, DefaultRefreshInterval, cfg.RefreshIntervalOrDefault())
+	failures, duration := cfg.EjectionOrDefault()
+	assert.Zero(t, failures)
+	assert.Zero(t, duration)
+
+	cfg.RefreshInterval = time.Minute
+	cfg.Ejection = &EjectionConfig{}
+	assert.Equal(t, time.Minute, cfg.RefreshIntervalOrDefault())
+	failures, duration = cfg.EjectionOrDefault()
+	assert.Equal(t, DefaultEjectionFailures, failures)
+	assert.Equal(t, DefaultEjectionDuration, duration)
+
+	cfg.Ejection = &EjectionConfig{ConsecutiveFailures: 2, Duration: time.Second}
+	failures, duration = cfg.EjectionOrDefault()
+	assert.Equal(t, 2, failures)
+	assert.Equal(t, time.Second, duration)
+}
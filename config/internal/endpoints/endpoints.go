@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package endpoints implements the resolution and the health tracking of the endpoints
+// between which the clients balance their requests.
+package endpoints // import "go.opentelemetry.io/collector/config/internal/endpoints"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// ResolverStatic uses each endpoint as its own address.
+	ResolverStatic = "static"
+	// ResolverDNS resolves the host of each endpoint to all its IP addresses.
+	ResolverDNS = "dns"
+
+	// PolicyRoundRobin sends the requests to the addresses in turn.
+	PolicyRoundRobin = "round_robin"
+	// PolicyLeastOutstanding sends each request to the address with the least requests in progress.
+	PolicyLeastOutstanding = "least_outstanding"
+)
+
+// Address is an address requests are sent to.
+type Address struct {
+	// Addr is the host:port to connect to.
+	Addr string
+	// Host is the host of the endpoint the address is resolved from.
+	Host string
+}
+
+// LookupHostFunc returns the IP addresses of a host, as net.Resolver.LookupHost.
+type LookupHostFunc func(ctx context.Context, host string) ([]string, error)
+
+// Resolve returns the addresses of the endpoints, as host:port. Without lookup, each
+// endpoint is its own address. Otherwise, the host of each endpoint is resolved
+// to all its IP addresses. The addresses of the endpoints which could be resolved are
+// returned along with the errors of the others.
+func Resolve(ctx context.Context, endpoints []string, lookup LookupHostFunc) ([]Address, error) {
+	var addrs []Address
+	var errs error
+	for _, endpoint := range endpoints {
+		host, port, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid endpoint %q: %w", endpoint, err))
+			continue
+		}
+		if lookup == nil {
+			addrs = append(addrs, Address{Addr: endpoint, Host: host})
+			continue
+		}
+		ips, err := lookup(ctx, host)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to resolve %q: %w", host, err))
+			continue
+		}
+		for _, ip := range ips {
+			addrs = append(addrs, Address{Addr: net.JoinHostPort(ip, port), Host: host})
+		}
+	}
+	return addrs, errs
+}
+
+// Health tracks the consecutive failures of the requests sent to an address, to eject it
+// from the balancing for a while once they reach a threshold.
+type Health struct {
+	failures     int
+	ejectedUntil time.Time
+}
+
+// Record records the outcome of a request. The address is ejected for the given duration
+// after threshold consecutive failures. A zero threshold disables ejection.
+func (h *Health) Record(failed bool, threshold int, duration time.Duration, now time.Time) {
+	if !failed {
+		h.failures = 0
+		return
+	}
+	h.failures++
+	if threshold > 0 && h.failures >= threshold {
+		h.failures = 0
+		h.ejectedUntil = now.Add(duration)
+	}
+}
+
+// Ejected returns whether the address is ejected at the given time.
+func (h *Health) Ejected(now time.Time) bool {
+	return now.Before(h.ejectedUntil)
+}
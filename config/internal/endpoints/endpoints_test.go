@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveStatic(t *testing.T) {
+	addrs, err := Resolve(context.Background(), []string{"backend-1:4317", "10.0.0.1:4317"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []Address{
+		{Addr: "backend-1:4317", Host: "backend-1"},
+		{Addr: "10.0.0.1:4317", Host: "10.0.0.1"},
+	}, addrs)
+}
+
+func TestResolveDNS(t *testing.T) {
+	lookup := func(_ context.Context, host string) ([]string, error) {
+		switch host {
+		case "backend":
+			return []string{"10.0.0.1", "::1"}, nil
+		default:
+			return nil, errors.New("no such host")
+		}
+	}
+	addrs, err := Resolve(context.Background(), []string{"backend:4317", "unknown:4317", "invalid"}, lookup)
+	assert.ErrorContains(t, err, `failed to resolve "unknown": no such host`)
+	assert.ErrorContains(t, err, `invalid endpoint "invalid"`)
+	assert.Equal(t, []Address{
+		{Addr: "10.0.0.1:4317", Host: "backend"},
+		{Addr: "[::1]:4317", Host: "backend"},
+	}, addrs)
+}
+
+func TestHealth(t *testing.T) {
+	now := time.Now()
+	h := &Health{}
+	h.Record(true, 2, time.Minute, now)
+	h.Record(false, 2, time.Minute, now)
+	h.Record(true, 2, time.Minute, now)
+	assert.False(t, h.Ejected(now), "a success resets the consecutive failures")
+
+	h.Record(true, 2, time.Minute, now)
+	assert.True(t, h.Ejected(now))
+	assert.True(t, h.Ejected(now.Add(59*time.Second)))
+	assert.False(t, h.Ejected(now.Add(time.Minute)))
+}
+
+func TestHealthEjectionDisabled(t *testing.T) {
+	now := time.Now()
+	h := &Health{}
+	for i := 0; i < 10; i++ {
+		h.Record(true, 0, time.Minute, now)
+	}
+	assert.False(t, h.Ejected(now))
+}
@@ -46,6 +46,10 @@ type Conf struct {
 	// This avoids running into an infinite recursion where Unmarshaler.Unmarshal and
 	// Conf.Unmarshal would call each other.
 	skipTopLevelUnmarshaler bool
+	// origins maps keys to the location where they were defined, if known.
+	origins map[string]Origin
+	// path is the key this Conf was extracted from using Sub, relative to the original Conf.
+	path string
 }
 
 // AllKeys returns all keys holding a value, regardless of where they are set.
@@ -84,7 +88,11 @@ func (l *Conf) Unmarshal(result any, opts ...UnmarshalOption) error {
 	for _, opt := range opts {
 		opt.apply(&set)
 	}
-	return decodeConfig(l, result, !set.ignoreUnused, l.skipTopLevelUnmarshaler)
+	err := decodeConfig(l, result, !set.ignoreUnused, l.skipTopLevelUnmarshaler)
+	if err != nil {
+		return locateUnusedKeys(err, l)
+	}
+	return nil
 }
 
 type marshalOption struct{}
@@ -160,7 +168,13 @@ func (l *Conf) IsSet(key string) bool {
 // Merge merges the input given configuration into the existing config.
 // Note that the given map may be modified.
 func (l *Conf) Merge(in *Conf) error {
-	return l.k.Merge(in.k)
+	if err := l.k.Merge(in.k); err != nil {
+		return err
+	}
+	for k, o := range in.origins {
+		l.setOrigin(k, o)
+	}
+	return nil
 }
 
 // Sub returns new Conf instance representing a sub-config of this instance.
@@ -174,10 +188,10 @@ func (l *Conf) Sub(key string) (*Conf, error) {
 
 	switch v := data.(type) {
 	case map[string]any:
-		return NewFromStringMap(v), nil
+		return l.subWithOrigins(key, NewFromStringMap(v)), nil
 	case expandedValue:
 		if m, ok := v.Value.(map[string]any); ok {
-			return NewFromStringMap(m), nil
+			return l.subWithOrigins(key, NewFromStringMap(m)), nil
 		}
 	}
 
@@ -202,8 +216,14 @@ func (l *Conf) ToStringMap() map[string]any {
 // Decodes time.Duration from strings. Allows custom unmarshaling for structs implementing
 // encoding.TextUnmarshaler. Allows custom unmarshaling for structs implementing confmap.Unmarshaler.
 func decodeConfig(m *Conf, result any, errorUnused bool, skipTopLevelUnmarshaler bool) error {
+	// The unused keys are collected in the metadata rather than reported by mapstructure,
+	// so that they can be reported with their path and the most similar valid key.
+	var md *mapstructure.Metadata
+	if errorUnused {
+		md = &mapstructure.Metadata{}
+	}
 	dc := &mapstructure.DecoderConfig{
-		ErrorUnused:      errorUnused,
+		Metadata:         md,
 		Result:           result,
 		TagName:          "mapstructure",
 		WeaklyTypedInput: false,
@@ -226,13 +246,16 @@ func decodeConfig(m *Conf, result any, errorUnused bool, skipTopLevelUnmarshaler
 	if err != nil {
 		return err
 	}
-	if err = decoder.Decode(m.toStringMapWithExpand()); err != nil {
+	input := m.toStringMapWithExpand()
+	if err = decoder.Decode(input); err != nil {
 		if strings.HasPrefix(err.Error(), "error decoding ''") {
-			return errors.Unwrap(err)
+			err = errors.Unwrap(err)
 		}
-		return err
 	}
-	return nil
+	if md != nil && len(md.Unused) > 0 {
+		return errors.Join(append([]error{err}, unusedKeysErrors(md.Unused, input, result)...)...)
+	}
+	return err
 }
 
 // encoderConfig returns a default encoder.EncoderConfig that includes
@@ -0,0 +1,414 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// UnusedKeysError is returned by Conf.Unmarshal when the configuration contains
+// keys that do not correspond to any field of the target struct.
+type UnusedKeysError struct {
+	// Keys are the unused keys, sorted by name.
+	Keys []UnusedKey
+
+	// name is the mapstructure name of the map holding the unused keys.
+	name string
+}
+
+// UnusedKey describes a key that was not used while unmarshaling a Conf.
+type UnusedKey struct {
+	// Name is the key as written in the configuration.
+	Name string
+	// Path is the path of the key, using KeyDelimiter as separator. It is relative to the
+	// outermost Conf that could locate the key, usually the one returned by the Resolver.
+	Path string
+	// Origin is where the key was defined, empty if unknown.
+	Origin Origin
+	// Suggestion is the valid key most similar to Name, empty if none is close enough.
+	Suggestion string
+
+	// relPath is the path of the key relative to the Conf that reported it.
+	relPath string
+}
+
+func (e *UnusedKeysError) Error() string {
+	keys := make([]string, 0, len(e.Keys))
+	for _, k := range e.Keys {
+		var details []string
+		if k.Path != k.relPath {
+			details = append(details, "at "+k.Path)
+		}
+		if k.Origin.URI != "" {
+			details = append(details, "from "+k.Origin.String())
+		}
+		if k.Suggestion != "" {
+			details = append(details, fmt.Sprintf("did you mean %q?", k.Suggestion))
+		}
+		if len(details) == 0 {
+			keys = append(keys, k.Name)
+			continue
+		}
+		keys = append(keys, k.Name+" ("+strings.Join(details, ", ")+")")
+	}
+	return fmt.Sprintf("'%s' has invalid keys: %s", e.name, strings.Join(keys, ", "))
+}
+
+// unusedKeysErrors groups the unused keys of input reported by mapstructure in its
+// decoding metadata by the name of the struct holding them, and returns an UnusedKeysError
+// for each struct, adding suggestions based on the fields of result.
+func unusedKeysErrors(unused []string, input, result any) []error {
+	var errs []*UnusedKeysError
+	byName := make(map[string]*UnusedKeysError)
+	for _, u := range unused {
+		name, key := splitUnusedKey(input, u)
+		uke, ok := byName[name]
+		if !ok {
+			uke = &UnusedKeysError{name: name}
+			byName[name] = uke
+			errs = append(errs, uke)
+		}
+		segments := nameSegments(name)
+		path := strings.Join(append(segments, key), KeyDelimiter)
+		uke.Keys = append(uke.Keys, UnusedKey{
+			Name:       key,
+			Path:       path,
+			Suggestion: closestKey(key, validKeys(reflect.ValueOf(result), segments)),
+			relPath:    path,
+		})
+	}
+	slices.SortFunc(errs, func(a, b *UnusedKeysError) int { return strings.Compare(a.name, b.name) })
+	ret := make([]error, 0, len(errs))
+	for _, uke := range errs {
+		slices.SortFunc(uke.Keys, func(a, b UnusedKey) int { return strings.Compare(a.Name, b.Name) })
+		ret = append(ret, uke)
+	}
+	return ret
+}
+
+// splitUnusedKey splits an unused key reported by mapstructure as "<name>.<key>", or as
+// "<key>" at the top level, into the mapstructure name of the struct holding the key and
+// the key itself. Since keys may contain dots, the name is the first prefix ending at
+// a dot which leads to a map of the decoded input holding the key.
+func splitUnusedKey(input any, unused string) (string, string) {
+	// The candidates are the top level, then every dot outside of brackets.
+	candidates := []int{-1}
+	depth := 0
+	for i := 0; i < len(unused); i++ {
+		switch unused[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '.':
+			if depth == 0 {
+				candidates = append(candidates, i)
+			}
+		}
+	}
+	for _, i := range candidates {
+		name, key := "", unused
+		if i >= 0 {
+			name, key = unused[:i], unused[i+1:]
+		}
+		if m, ok := inputAt(input, nameSegments(name)).(map[string]any); ok {
+			if _, ok = m[key]; ok {
+				return name, key
+			}
+		}
+	}
+	if last := candidates[len(candidates)-1]; last >= 0 {
+		return unused[:last], unused[last+1:]
+	}
+	return "", unused
+}
+
+// inputAt returns the value found following segments from the decoded input, or nil
+// if the path cannot be followed.
+func inputAt(input any, segments []string) any {
+	for _, seg := range segments {
+		if exp, ok := input.(expandedValue); ok {
+			input = exp.Value
+		}
+		switch v := input.(type) {
+		case map[string]any:
+			input = v[seg]
+		case []any:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil
+			}
+			input = v[idx]
+		default:
+			return nil
+		}
+	}
+	if exp, ok := input.(expandedValue); ok {
+		return exp.Value
+	}
+	return input
+}
+
+// locateUnusedKeys sets the path and origin of the unused keys reported in the err
+// tree using the keys of conf. Keys that are not set in conf are searched by suffix,
+// since they may have been reported by a Conf built from a sub-map of conf.
+// Paths are made relative to the Conf that conf was extracted from using Sub.
+func locateUnusedKeys(err error, conf *Conf) error {
+	return replaceLeafErrors(err, func(leaf error) error {
+		uke, ok := leaf.(*UnusedKeysError)
+		if !ok {
+			return leaf
+		}
+		located := &UnusedKeysError{name: uke.name, Keys: slices.Clone(uke.Keys)}
+		for i := range located.Keys {
+			locateUnusedKey(&located.Keys[i], conf)
+		}
+		return located
+	})
+}
+
+func locateUnusedKey(key *UnusedKey, conf *Conf) {
+	path := key.Path
+	if !conf.IsSet(path) {
+		suffix := KeyDelimiter + path
+		path = ""
+		for _, k := range conf.AllKeys() {
+			idx := strings.Index(k+KeyDelimiter, suffix+KeyDelimiter)
+			if idx < 0 {
+				continue
+			}
+			candidate := k[:idx+len(suffix)]
+			if path != "" && path != candidate {
+				// Ambiguous, keep the path known so far.
+				return
+			}
+			path = candidate
+		}
+		if path == "" {
+			return
+		}
+	}
+	if o, ok := conf.Origin(path); ok {
+		key.Origin = o
+	}
+	if conf.path != "" {
+		path = conf.path + KeyDelimiter + path
+	}
+	key.Path = path
+}
+
+// replaceLeafErrors returns err with every leaf of its tree replaced by fn(leaf).
+// Errors wrapping a replaced leaf are rebuilt so that their message stays up to date;
+// this is only possible for errors whose message ends with the wrapped error message,
+// like the ones created by fmt.Errorf("...: %w") and errors.Join.
+func replaceLeafErrors(err error, fn func(error) error) error {
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		errs := e.Unwrap()
+		newErrs := make([]error, len(errs))
+		changed := false
+		for i, child := range errs {
+			newErrs[i] = replaceLeafErrors(child, fn)
+			changed = changed || newErrs[i] != child
+		}
+		if !changed {
+			return err
+		}
+		return errors.Join(newErrs...)
+	case interface{ Unwrap() error }:
+		child := e.Unwrap()
+		if child == nil {
+			return fn(err)
+		}
+		newChild := replaceLeafErrors(child, fn)
+		prefix, ok := strings.CutSuffix(err.Error(), child.Error())
+		if newChild == child || !ok {
+			return err
+		}
+		return fmt.Errorf("%s%w", prefix, newChild)
+	}
+	return fn(err)
+}
+
+// nameSegments splits a mapstructure field name like "a.b[c].d" into its segments.
+func nameSegments(name string) []string {
+	var segments []string
+	var cur strings.Builder
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; c {
+		case '.':
+			if cur.Len() > 0 {
+				segments = append(segments, cur.String())
+				cur.Reset()
+			}
+		case '[':
+			if cur.Len() > 0 {
+				segments = append(segments, cur.String())
+				cur.Reset()
+			}
+			end := strings.IndexByte(name[i:], ']')
+			if end < 0 {
+				cur.WriteString(name[i+1:])
+				i = len(name)
+				continue
+			}
+			segments = append(segments, name[i+1:i+end])
+			i += end
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	if cur.Len() > 0 {
+		segments = append(segments, cur.String())
+	}
+	return segments
+}
+
+// validKeys returns the keys accepted by the struct found following segments from v,
+// or nil if the path cannot be followed.
+func validKeys(v reflect.Value, segments []string) []string {
+	for {
+		v = indirect(v)
+		if !v.IsValid() {
+			return nil
+		}
+		if len(segments) == 0 {
+			break
+		}
+		seg := segments[0]
+		segments = segments[1:]
+		switch v.Kind() {
+		case reflect.Struct:
+			v = structField(v, seg)
+		case reflect.Map:
+			elem := reflect.Value{}
+			if v.Type().Key().Kind() == reflect.String {
+				elem = v.MapIndex(reflect.ValueOf(seg).Convert(v.Type().Key()))
+			}
+			if !elem.IsValid() {
+				elem = reflect.New(v.Type().Elem()).Elem()
+			}
+			v = elem
+		case reflect.Slice, reflect.Array:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= v.Len() {
+				v = reflect.New(v.Type().Elem()).Elem()
+				continue
+			}
+			v = v.Index(idx)
+		default:
+			return nil
+		}
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	var keys []string
+	forEachField(v, func(name string, _ reflect.Value) bool {
+		keys = append(keys, name)
+		return true
+	})
+	return keys
+}
+
+// indirect dereferences pointers and interfaces, allocating zero values for nil pointers.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			if v.Kind() == reflect.Interface {
+				return reflect.Value{}
+			}
+			v = reflect.New(v.Type().Elem()).Elem()
+			continue
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func structField(v reflect.Value, name string) reflect.Value {
+	var found reflect.Value
+	forEachField(v, func(fieldName string, fv reflect.Value) bool {
+		if fieldName == name {
+			found = fv
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// forEachField calls fn with the mapstructure name and value of every field of the
+// struct v, including the ones of squashed embedded structs, until fn returns false.
+func forEachField(v reflect.Value, fn func(string, reflect.Value) bool) bool {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tagValue := field.Tag.Get("mapstructure")
+		name, opts, _ := strings.Cut(tagValue, ",")
+		if name == "-" {
+			continue
+		}
+		if slices.Contains(strings.Split(opts, ","), "squash") {
+			if fv := indirect(v.Field(i)); fv.IsValid() && fv.Kind() == reflect.Struct && !forEachField(fv, fn) {
+				return false
+			}
+			continue
+		}
+		if !field.IsExported() || slices.Contains(strings.Split(opts, ","), "remain") {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if !fn(name, v.Field(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// closestKey returns the candidate most similar to key, or an empty string if none
+// is within an edit distance of a third of the key length (at least one edit).
+func closestKey(key string, candidates []string) string {
+	best := ""
+	bestDist := max(1, len(key)/3) + 1
+	for _, c := range candidates {
+		if d := editDistance(strings.ToLower(key), strings.ToLower(c)); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the edit distance between a and b, counting insertions,
+// deletions, substitutions and transpositions of adjacent characters.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type suggestionsEmbedded struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+type suggestionsProtocols struct {
+	GRPC *suggestionsEmbedded `mapstructure:"grpc"`
+	HTTP *suggestionsEmbedded `mapstructure:"http"`
+}
+
+type suggestionsConfig struct {
+	suggestionsEmbedded `mapstructure:",squash"`
+	Protocols           suggestionsProtocols            `mapstructure:"protocols"`
+	Servers             map[string]suggestionsEmbedded  `mapstructure:"servers"`
+	Verbosity           string                          `mapstructure:"verbosity"`
+	Ignored             string                          `mapstructure:"-"`
+	Nested              *suggestionsUnmarshalerProtocol `mapstructure:"nested"`
+}
+
+type suggestionsUnmarshalerProtocol struct {
+	Protocols suggestionsProtocols `mapstructure:"protocols"`
+}
+
+func (p *suggestionsUnmarshalerProtocol) Unmarshal(conf *Conf) error {
+	return conf.Unmarshal(p)
+}
+
+func TestUnmarshalUnusedKeysSuggestions(t *testing.T) {
+	tests := []struct {
+		name        string
+		conf        map[string]any
+		expectedErr string
+		expectedKey UnusedKey
+	}{
+		{
+			name:        "top_level_case",
+			conf:        map[string]any{"verBosity": "detailed"},
+			expectedErr: `'' has invalid keys: verBosity (did you mean "verbosity"?)`,
+			expectedKey: UnusedKey{Name: "verBosity", Path: "verBosity", Suggestion: "verbosity"},
+		},
+		{
+			name:        "squashed",
+			conf:        map[string]any{"endpont": "localhost:4317"},
+			expectedErr: `'' has invalid keys: endpont (did you mean "endpoint"?)`,
+			expectedKey: UnusedKey{Name: "endpont", Path: "endpont", Suggestion: "endpoint"},
+		},
+		{
+			name:        "nested",
+			conf:        map[string]any{"protocols": map[string]any{"htttp": nil}},
+			expectedErr: `'protocols' has invalid keys: htttp (did you mean "http"?)`,
+			expectedKey: UnusedKey{Name: "htttp", Path: "protocols::htttp", Suggestion: "http"},
+		},
+		{
+			name:        "map_value",
+			conf:        map[string]any{"servers": map[string]any{"a": map[string]any{"endpoint": "x", "endpoints": "y"}}},
+			expectedErr: `'servers[a]' has invalid keys: endpoints (did you mean "endpoint"?)`,
+			expectedKey: UnusedKey{Name: "endpoints", Path: "servers::a::endpoints", Suggestion: "endpoint"},
+		},
+		{
+			name:        "no_suggestion",
+			conf:        map[string]any{"unknown_section": nil},
+			expectedErr: `'' has invalid keys: unknown_section`,
+			expectedKey: UnusedKey{Name: "unknown_section", Path: "unknown_section"},
+		},
+		{
+			name:        "through_unmarshaler",
+			conf:        map[string]any{"nested": map[string]any{"protocols": map[string]any{"grcp": nil}}},
+			expectedErr: `'protocols' has invalid keys: grcp (at nested::protocols::grcp, did you mean "grpc"?)`,
+			expectedKey: UnusedKey{Name: "grcp", Path: "nested::protocols::grcp", Suggestion: "grpc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewFromStringMap(tt.conf).Unmarshal(&suggestionsConfig{})
+			require.ErrorContains(t, err, tt.expectedErr)
+
+			var uke *UnusedKeysError
+			require.ErrorAs(t, err, &uke)
+			require.Len(t, uke.Keys, 1)
+			assert.Equal(t, tt.expectedKey.Name, uke.Keys[0].Name)
+			assert.Equal(t, tt.expectedKey.Path, uke.Keys[0].Path)
+			assert.Equal(t, tt.expectedKey.Suggestion, uke.Keys[0].Suggestion)
+			assert.Empty(t, uke.Keys[0].Origin)
+		})
+	}
+}
+
+func TestUnmarshalUnusedKeysMultiple(t *testing.T) {
+	err := NewFromStringMap(map[string]any{"endpont": "x", "foo": "bar"}).Unmarshal(&suggestionsConfig{})
+	assert.EqualError(t, err, `'' has invalid keys: endpont (did you mean "endpoint"?), foo`)
+}
+
+func TestUnmarshalUnusedKeysSeparators(t *testing.T) {
+	err := NewFromStringMap(map[string]any{
+		"a, b":      "x",
+		"protocols": map[string]any{"grpc.endpoint": "y"},
+	}).Unmarshal(&suggestionsConfig{})
+
+	var ukes []*UnusedKeysError
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var uke *UnusedKeysError
+		require.ErrorAs(t, e, &uke)
+		ukes = append(ukes, uke)
+	}
+	require.Len(t, ukes, 2)
+	assert.Equal(t, []UnusedKey{{Name: "a, b", Path: "a, b", relPath: "a, b"}}, ukes[0].Keys)
+	assert.Equal(t, []UnusedKey{{Name: "grpc.endpoint", Path: "protocols::grpc.endpoint", relPath: "protocols::grpc.endpoint"}}, ukes[1].Keys)
+}
+
+func TestUnmarshalUnusedKeysOrigin(t *testing.T) {
+	conf := NewFromStringMap(map[string]any{"receivers": map[string]any{"nested": map[string]any{"protocols": map[string]any{"grcp": nil}}}})
+	conf.setOrigins("file:config.yaml", nil)
+
+	sub, err := conf.Sub("receivers")
+	require.NoError(t, err)
+	err = sub.Unmarshal(&suggestionsConfig{})
+	require.ErrorContains(t, err, `'protocols' has invalid keys: grcp (at receivers::nested::protocols::grcp, from file:config.yaml, did you mean "grpc"?)`)
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("", ""))
+	assert.Equal(t, 3, editDistance("", "abc"))
+	assert.Equal(t, 1, editDistance("htttp", "http"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	assert.Equal(t, 1, editDistance("grcp", "grpc"))
+}
+
+func TestNameSegments(t *testing.T) {
+	assert.Nil(t, nameSegments(""))
+	assert.Equal(t, []string{"a", "b", "c.d", "e"}, nameSegments("a.b[c.d].e"))
+	assert.Equal(t, []string{"extensions", "0"}, nameSegments("extensions[0]"))
+	assert.Equal(t, []string{"traces"}, nameSegments("[traces]"))
+}
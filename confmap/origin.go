@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Origin describes where a configuration value was defined.
+type Origin struct {
+	// URI of the configuration that defined the value, as passed to the Resolver.
+	URI string
+	// Line of the key in the retrieved YAML document, 0 if unknown.
+	Line int
+	// Column of the key in the retrieved YAML document, 0 if unknown.
+	Column int
+}
+
+// String returns the origin as "uri:line:column", omitting the position if unknown.
+func (o Origin) String() string {
+	if o.Line == 0 {
+		return o.URI
+	}
+	return o.URI + ":" + strconv.Itoa(o.Line) + ":" + strconv.Itoa(o.Column)
+}
+
+// position is the location of a key in a YAML document.
+type position struct {
+	line   int
+	column int
+}
+
+// Origin returns where the given key was defined, and whether it is known.
+// The origin is only tracked for Conf instances returned by the Resolver, and
+// the ones derived from them using Sub.
+//
+// If key was not directly defined (e.g. it was created by a Converter or it
+// belongs to a value expanded from a "${...}" reference), the URI of the closest
+// defined ancestor or descendant is returned, without position.
+func (l *Conf) Origin(key string) (Origin, bool) {
+	if o, ok := l.origins[key]; ok {
+		return o, true
+	}
+	for parent := key; ; {
+		idx := strings.LastIndex(parent, KeyDelimiter)
+		if idx < 0 {
+			break
+		}
+		parent = parent[:idx]
+		if o, ok := l.origins[parent]; ok {
+			return Origin{URI: o.URI}, true
+		}
+	}
+	prefix := key + KeyDelimiter
+	for k, o := range l.origins {
+		if strings.HasPrefix(k, prefix) {
+			return Origin{URI: o.URI}, true
+		}
+	}
+	return Origin{}, false
+}
+
+// setOrigin records o as the origin of key.
+func (l *Conf) setOrigin(key string, o Origin) {
+	if l.origins == nil {
+		l.origins = make(map[string]Origin)
+	}
+	l.origins[key] = o
+}
+
+// setOrigins records uri as the origin of all the keys currently set in l,
+// using the given positions of the keys in the retrieved document, if any.
+func (l *Conf) setOrigins(uri string, positions map[string]position) {
+	for k, pos := range positions {
+		l.setOrigin(k, Origin{URI: uri, Line: pos.line, Column: pos.column})
+	}
+	for _, k := range l.AllKeys() {
+		if _, ok := positions[k]; !ok {
+			l.setOrigin(k, Origin{URI: uri})
+		}
+	}
+}
+
+// subWithOrigins copies into sub the origins of the keys under key, and records
+// the path of sub relative to the Conf l was extracted from.
+func (l *Conf) subWithOrigins(key string, sub *Conf) *Conf {
+	sub.path = key
+	if l.path != "" {
+		sub.path = l.path + KeyDelimiter + key
+	}
+	prefix := key + KeyDelimiter
+	for k, o := range l.origins {
+		if rest, ok := strings.CutPrefix(k, prefix); ok {
+			sub.setOrigin(rest, o)
+		}
+	}
+	return sub
+}
+
+// yamlPositions returns the position of every mapping key of the YAML document,
+// indexed by its path using KeyDelimiter as separator.
+func yamlPositions(yamlBytes []byte) map[string]position {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(yamlBytes, &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+	positions := make(map[string]position)
+	addYAMLPositions(doc.Content[0], "", positions)
+	return positions
+}
+
+func addYAMLPositions(node *yaml.Node, prefix string, positions map[string]position) {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		if keyNode.Tag == "!!merge" {
+			// Keys merged with "<<" keep the position of their definition.
+			addYAMLPositions(valueNode, prefix, positions)
+			continue
+		}
+		key := keyNode.Value
+		if prefix != "" {
+			key = prefix + KeyDelimiter + key
+		}
+		positions[key] = position{line: keyNode.Line, column: keyNode.Column}
+		addYAMLPositions(valueNode, key, positions)
+	}
+}
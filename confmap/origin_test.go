@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOriginString(t *testing.T) {
+	assert.Equal(t, "", Origin{}.String())
+	assert.Equal(t, "file:config.yaml", Origin{URI: "file:config.yaml"}.String())
+	assert.Equal(t, "file:config.yaml:3:5", Origin{URI: "file:config.yaml", Line: 3, Column: 5}.String())
+}
+
+func TestYAMLPositions(t *testing.T) {
+	positions := yamlPositions([]byte(`
+defaults: &defaults
+  endpoint: localhost:4317
+receivers:
+  otlp:
+    <<: *defaults
+    protocols:
+      grpc:
+list:
+  - a: b
+`))
+	assert.Equal(t, map[string]position{
+		"defaults":                         {line: 2, column: 1},
+		"defaults::endpoint":               {line: 3, column: 3},
+		"receivers":                        {line: 4, column: 1},
+		"receivers::otlp":                  {line: 5, column: 3},
+		"receivers::otlp::endpoint":        {line: 3, column: 3},
+		"receivers::otlp::protocols":       {line: 7, column: 5},
+		"receivers::otlp::protocols::grpc": {line: 8, column: 7},
+		"list":                             {line: 9, column: 1},
+	}, positions)
+
+	assert.Nil(t, yamlPositions([]byte("[")))
+	assert.Nil(t, yamlPositions(nil))
+}
+
+func TestConfOrigin(t *testing.T) {
+	conf := NewFromStringMap(map[string]any{
+		"receivers": map[string]any{
+			"otlp": map[string]any{
+				"endpoint": "localhost:4317",
+			},
+		},
+	})
+	_, ok := conf.Origin("receivers::otlp")
+	assert.False(t, ok)
+
+	conf.setOrigins("file:config.yaml", map[string]position{
+		"receivers":       {line: 1, column: 1},
+		"receivers::otlp": {line: 2, column: 3},
+	})
+
+	o, ok := conf.Origin("receivers::otlp")
+	require.True(t, ok)
+	assert.Equal(t, Origin{URI: "file:config.yaml", Line: 2, Column: 3}, o)
+
+	// Leaf keys without known position only report the URI.
+	o, ok = conf.Origin("receivers::otlp::endpoint")
+	require.True(t, ok)
+	assert.Equal(t, Origin{URI: "file:config.yaml"}, o)
+
+	// Keys that are not set report the URI of their closest ancestor.
+	o, ok = conf.Origin("receivers::otlp::protocols")
+	require.True(t, ok)
+	assert.Equal(t, Origin{URI: "file:config.yaml"}, o)
+
+	_, ok = conf.Origin("exporters")
+	assert.False(t, ok)
+
+	sub, err := conf.Sub("receivers")
+	require.NoError(t, err)
+	o, ok = sub.Origin("otlp")
+	require.True(t, ok)
+	assert.Equal(t, Origin{URI: "file:config.yaml", Line: 2, Column: 3}, o)
+}
+
+func TestResolverOrigins(t *testing.T) {
+	resolver, err := NewResolver(ResolverSettings{
+		URIs: []string{"yaml:first", "yaml:second"},
+		ProviderFactories: []ProviderFactory{
+			newFakeProvider("yaml", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+				if uri == "yaml:first" {
+					return NewRetrievedFromYAML([]byte("receivers:\n  otlp:\n    endpoint: localhost:4317\n"))
+				}
+				return NewRetrievedFromYAML([]byte("exporters:\n  debug:\n    verbosity: detailed\nreceivers:\n  otlp:\n    endpoint: 0.0.0.0:4317\n"))
+			}),
+		},
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+
+	o, ok := conf.Origin("exporters::debug::verbosity")
+	require.True(t, ok)
+	assert.Equal(t, Origin{URI: "yaml:second", Line: 3, Column: 5}, o)
+
+	// The last configuration setting a key wins.
+	o, ok = conf.Origin("receivers::otlp::endpoint")
+	require.True(t, ok)
+	assert.Equal(t, Origin{URI: "yaml:second", Line: 6, Column: 5}, o)
+}
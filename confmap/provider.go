@@ -104,12 +104,16 @@ type Retrieved struct {
 
 	stringRepresentation string
 	isSetString          bool
+
+	// positions of the keys in the retrieved YAML document, if any.
+	positions map[string]position
 }
 
 type retrievedSettings struct {
 	stringRepresentation string
 	isSetString          bool
 	closeFunc            CloseFunc
+	positions            map[string]position
 }
 
 // RetrievedOption options to customize Retrieved values.
@@ -131,6 +135,12 @@ func WithRetrievedClose(closeFunc CloseFunc) RetrievedOption {
 	})
 }
 
+func withYAMLPositions(yamlBytes []byte) RetrievedOption {
+	return retrievedOptionFunc(func(settings *retrievedSettings) {
+		settings.positions = yamlPositions(yamlBytes)
+	})
+}
+
 func withStringRepresentation(stringRepresentation string) RetrievedOption {
 	return retrievedOptionFunc(func(settings *retrievedSettings) {
 		settings.stringRepresentation = stringRepresentation
@@ -153,6 +163,8 @@ func NewRetrievedFromYAML(yamlBytes []byte, opts ...RetrievedOption) (*Retrieved
 	case string:
 		val := string(yamlBytes)
 		return NewRetrieved(val, append(opts, withStringRepresentation(val))...)
+	case map[string]any:
+		opts = append(opts, withStringRepresentation(string(yamlBytes)), withYAMLPositions(yamlBytes))
 	default:
 		opts = append(opts, withStringRepresentation(string(yamlBytes)))
 	}
@@ -178,6 +190,7 @@ func NewRetrieved(rawConf any, opts ...RetrievedOption) (*Retrieved, error) {
 		closeFunc:            set.closeFunc,
 		stringRepresentation: set.stringRepresentation,
 		isSetString:          set.isSetString,
+		positions:            set.positions,
 	}, nil
 }
 
@@ -170,6 +170,7 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 		if err != nil {
 			return nil, err
 		}
+		retCfgMap.setOrigins(uri.asString(), ret.positions)
 		if err = retMap.Merge(retCfgMap); err != nil {
 			return nil, err
 		}
@@ -183,7 +184,9 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 		}
 		cfgMap[k] = escapeDollarSigns(val)
 	}
-	retMap = NewFromStringMap(cfgMap)
+	expandedMap := NewFromStringMap(cfgMap)
+	expandedMap.origins = retMap.origins
+	retMap = expandedMap
 
 	// Apply the converters in the given order.
 	for _, confConv := range mr.converters {
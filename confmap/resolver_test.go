@@ -432,3 +432,33 @@ func TestResolverDefaultProviderSet(t *testing.T) {
 	_, ok := r.providers["env"]
 	assert.True(t, ok)
 }
+
+func TestResolverUnusedKeysOrigin(t *testing.T) {
+	resolver, err := NewResolver(ResolverSettings{
+		URIs: []string{"mock:", "other:config"},
+		ProviderFactories: []ProviderFactory{
+			newMockProvider(&mockProvider{retM: map[string]any{"receivers": map[string]any{"nested": map[string]any{}}}}),
+			newFakeProvider("other", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(map[string]any{"receivers": map[string]any{"nested": map[string]any{"protocols": map[string]any{"grcp": nil}}}})
+			}),
+		},
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+
+	var cfg struct {
+		Receivers suggestionsConfig `mapstructure:"receivers"`
+	}
+	err = conf.Unmarshal(&cfg)
+	var uke *UnusedKeysError
+	require.ErrorAs(t, err, &uke)
+	require.Len(t, uke.Keys, 1)
+	assert.Equal(t, UnusedKey{
+		Name:       "grcp",
+		Path:       "receivers::nested::protocols::grcp",
+		Origin:     Origin{URI: "other:config"},
+		Suggestion: "grpc",
+		relPath:    "protocols::grcp",
+	}, uke.Keys[0])
+}
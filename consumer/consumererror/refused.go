@@ -0,0 +1,11 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import "errors"
+
+// ErrDataRefused is returned, possibly wrapped, by the consumers refusing data because of
+// high memory usage, e.g. the memory limiter processor. The data isn't permanently rejected
+// and can be sent again once memory usage is lower.
+var ErrDataRefused = errors.New("data refused due to high memory usage")
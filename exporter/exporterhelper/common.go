@@ -4,6 +4,8 @@
 package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
 
 import (
+	"context"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/consumer"
@@ -69,3 +71,10 @@ func WithCapabilities(capabilities consumer.Capabilities) Option {
 func WithBatcher(cfg exporterbatcher.Config) Option {
 	return internal.WithBatcher(cfg)
 }
+
+// RecordRejectedItems records items of the current export request which were sent successfully,
+// but rejected by the destination, such as the rejected items of OTLP partial success responses.
+// ctx must be the context passed to the function pushing the data.
+func RecordRejectedItems(ctx context.Context, rejected int64) {
+	internal.RecordRejectedItems(ctx, rejected)
+}
@@ -5,6 +5,7 @@ package internal // import "go.opentelemetry.io/collector/exporter/exporterhelpe
 
 import (
 	"context"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -59,7 +60,7 @@ func (or *ObsReport) StartTracesOp(ctx context.Context) context.Context {
 // EndTracesOp completes the export operation that was started with startTracesOp.
 func (or *ObsReport) EndTracesOp(ctx context.Context, numSpans int, err error) {
 	numSent, numFailedToSend := toNumItems(numSpans, err)
-	or.recordMetrics(context.WithoutCancel(ctx), pipeline.SignalTraces, numSent, numFailedToSend)
+	or.recordMetrics(context.WithoutCancel(ctx), pipeline.SignalTraces, numSent, numFailedToSend, rejectedItems(ctx))
 	endSpan(ctx, err, numSent, numFailedToSend, SentSpansKey, FailedToSendSpansKey)
 }
 
@@ -76,7 +77,7 @@ func (or *ObsReport) StartMetricsOp(ctx context.Context) context.Context {
 // If needed, report your use case in https://github.com/open-telemetry/opentelemetry-collector/issues/10592.
 func (or *ObsReport) EndMetricsOp(ctx context.Context, numMetricPoints int, err error) {
 	numSent, numFailedToSend := toNumItems(numMetricPoints, err)
-	or.recordMetrics(context.WithoutCancel(ctx), pipeline.SignalMetrics, numSent, numFailedToSend)
+	or.recordMetrics(context.WithoutCancel(ctx), pipeline.SignalMetrics, numSent, numFailedToSend, rejectedItems(ctx))
 	endSpan(ctx, err, numSent, numFailedToSend, SentMetricPointsKey, FailedToSendMetricPointsKey)
 }
 
@@ -90,7 +91,7 @@ func (or *ObsReport) StartLogsOp(ctx context.Context) context.Context {
 // EndLogsOp completes the export operation that was started with startLogsOp.
 func (or *ObsReport) EndLogsOp(ctx context.Context, numLogRecords int, err error) {
 	numSent, numFailedToSend := toNumItems(numLogRecords, err)
-	or.recordMetrics(context.WithoutCancel(ctx), pipeline.SignalLogs, numSent, numFailedToSend)
+	or.recordMetrics(context.WithoutCancel(ctx), pipeline.SignalLogs, numSent, numFailedToSend, rejectedItems(ctx))
 	endSpan(ctx, err, numSent, numFailedToSend, SentLogRecordsKey, FailedToSendLogRecordsKey)
 }
 
@@ -112,25 +113,48 @@ func (or *ObsReport) EndProfilesOp(ctx context.Context, numSpans int, err error)
 func (or *ObsReport) startOp(ctx context.Context, operationSuffix string) context.Context {
 	spanName := or.spanNamePrefix + operationSuffix
 	ctx, _ = or.tracer.Start(ctx, spanName)
-	return ctx
+	return context.WithValue(ctx, rejectedItemsKey{}, new(atomic.Int64))
 }
 
-func (or *ObsReport) recordMetrics(ctx context.Context, signal pipeline.Signal, sent, failed int64) {
-	var sentMeasure, failedMeasure metric.Int64Counter
+func (or *ObsReport) recordMetrics(ctx context.Context, signal pipeline.Signal, sent, failed, rejected int64) {
+	var sentMeasure, failedMeasure, rejectedMeasure metric.Int64Counter
 	switch signal {
 	case pipeline.SignalTraces:
 		sentMeasure = or.TelemetryBuilder.ExporterSentSpans
 		failedMeasure = or.TelemetryBuilder.ExporterSendFailedSpans
+		rejectedMeasure = or.TelemetryBuilder.ExporterRejectedSpans
 	case pipeline.SignalMetrics:
 		sentMeasure = or.TelemetryBuilder.ExporterSentMetricPoints
 		failedMeasure = or.TelemetryBuilder.ExporterSendFailedMetricPoints
+		rejectedMeasure = or.TelemetryBuilder.ExporterRejectedMetricPoints
 	case pipeline.SignalLogs:
 		sentMeasure = or.TelemetryBuilder.ExporterSentLogRecords
 		failedMeasure = or.TelemetryBuilder.ExporterSendFailedLogRecords
+		rejectedMeasure = or.TelemetryBuilder.ExporterRejectedLogRecords
 	}
 
 	sentMeasure.Add(ctx, sent, or.otelAttrs)
 	failedMeasure.Add(ctx, failed, or.otelAttrs)
+	if rejected > 0 {
+		rejectedMeasure.Add(ctx, rejected, or.otelAttrs)
+	}
+}
+
+type rejectedItemsKey struct{}
+
+// RecordRejectedItems records items sent successfully in the export operation of ctx,
+// but rejected by the destination.
+func RecordRejectedItems(ctx context.Context, rejected int64) {
+	if counter, ok := ctx.Value(rejectedItemsKey{}).(*atomic.Int64); ok {
+		counter.Add(rejected)
+	}
+}
+
+func rejectedItems(ctx context.Context) int64 {
+	if counter, ok := ctx.Value(rejectedItemsKey{}).(*atomic.Int64); ok {
+		return counter.Load()
+	}
+	return 0
 }
 
 func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sentItemsKey, failedToSendItemsKey string) {
@@ -12,9 +12,12 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/exporter"
 )
 
@@ -235,6 +238,50 @@ type testParams struct {
 	err   error
 }
 
+func TestExportRejectedItems(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	set := componenttest.NewNopTelemetrySettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	set.MetricsLevel = configtelemetry.LevelBasic
+	obsrep, err := NewExporter(ObsReportSettings{
+		ExporterID:             exporterID,
+		ExporterCreateSettings: exporter.Settings{ID: exporterID, TelemetrySettings: set, BuildInfo: component.NewDefaultBuildInfo()},
+	})
+	require.NoError(t, err)
+
+	ctx := obsrep.StartTracesOp(context.Background())
+	RecordRejectedItems(ctx, 2)
+	RecordRejectedItems(ctx, 3)
+	obsrep.EndTracesOp(ctx, 10, nil)
+
+	ctx = obsrep.StartLogsOp(context.Background())
+	RecordRejectedItems(ctx, 4)
+	obsrep.EndLogsOp(ctx, 10, nil)
+
+	ctx = obsrep.StartMetricsOp(context.Background())
+	obsrep.EndMetricsOp(ctx, 10, nil)
+
+	// Recording without an export operation is ignored.
+	RecordRejectedItems(context.Background(), 1)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range sum.DataPoints {
+					values[m.Name] += dp.Value
+				}
+			}
+		}
+	}
+	assert.Equal(t, int64(5), values["otelcol_exporter_rejected_spans"])
+	assert.Equal(t, int64(4), values["otelcol_exporter_rejected_log_records"])
+	assert.NotContains(t, values, "otelcol_exporter_rejected_metric_points")
+	assert.Equal(t, int64(10), values["otelcol_exporter_sent_spans"])
+}
+
 func testTelemetry(t *testing.T, id component.ID, testFunc func(t *testing.T, tt componenttest.TestTelemetry)) {
 	tt, err := componenttest.SetupTelemetry(id)
 	require.NoError(t, err)
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package exportstatus tracks the conditions degrading an exporter, such as partial
+// success responses and failing over to a secondary endpoint, to report them through
+// component status.
+package exportstatus // import "go.opentelemetry.io/collector/exporter/internal/exportstatus"
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Reporter reports an error while the exporter is failed over to a secondary
+// endpoint or, if enabled, since the last partial success response, and nil once
+// none of these conditions hold anymore. Failing over takes precedence, so that a full
+// success on a secondary endpoint doesn't hide it.
+//
+// A nil *Reporter reports nothing.
+type Reporter struct {
+	partialSuccess bool
+	report         func(err error)
+
+	mu       sync.Mutex
+	failover error
+	partial  error
+	degraded bool
+}
+
+// New returns a Reporter calling report with the error to report as a recoverable
+// error, or nil to report StatusOK. Partial success responses are only reported if
+// partialSuccess is true.
+func New(partialSuccess bool, report func(err error)) *Reporter {
+	return &Reporter{partialSuccess: partialSuccess, report: report}
+}
+
+// PartialSuccess records the partial success of an export response, where a full
+// success has no message and no rejected items.
+func (r *Reporter) PartialSuccess(message string, rejected int64) {
+	if r == nil || !r.partialSuccess {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if message == "" && rejected == 0 {
+		if r.partial != nil {
+			r.partial = nil
+			r.reportLocked()
+		}
+		return
+	}
+	if message == "" {
+		message = "no error message"
+	}
+	r.partial = fmt.Errorf("partial success response, %d items rejected: %s", rejected, message)
+	r.reportLocked()
+}
+
+// Failover records that the exporter failed over to a secondary endpoint, or that it
+// exports to the primary endpoint again when err is nil.
+func (r *Reporter) Failover(err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failover = err
+	r.reportLocked()
+}
+
+func (r *Reporter) reportLocked() {
+	switch {
+	case r.failover != nil:
+		r.degraded = true
+		r.report(r.failover)
+	case r.partial != nil:
+		r.degraded = true
+		r.report(r.partial)
+	case r.degraded:
+		r.degraded = false
+		r.report(nil)
+	}
+}
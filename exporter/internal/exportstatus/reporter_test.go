@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exportstatus
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reported struct {
+	errs []error
+}
+
+func (r *reported) report(err error) {
+	r.errs = append(r.errs, err)
+}
+
+func TestPartialSuccess(t *testing.T) {
+	rep := &reported{}
+	r := New(true, rep.report)
+
+	r.PartialSuccess("", 0)
+	assert.Empty(t, rep.errs)
+
+	r.PartialSuccess("too old", 3)
+	r.PartialSuccess("", 2)
+	require.Len(t, rep.errs, 2)
+	require.EqualError(t, rep.errs[0], "partial success response, 3 items rejected: too old")
+	require.EqualError(t, rep.errs[1], "partial success response, 2 items rejected: no error message")
+
+	r.PartialSuccess("", 0)
+	r.PartialSuccess("", 0)
+	require.Len(t, rep.errs, 3)
+	require.NoError(t, rep.errs[2])
+
+	// Nothing is reported when disabled.
+	disabled := New(false, rep.report)
+	disabled.PartialSuccess("too old", 3)
+	var nilReporter *Reporter
+	nilReporter.PartialSuccess("too old", 3)
+	nilReporter.Failover(errors.New("failed over"))
+	assert.Len(t, rep.errs, 3)
+}
+
+func TestFailover(t *testing.T) {
+	rep := &reported{}
+	r := New(true, rep.report)
+
+	r.Failover(errors.New("failed over"))
+	r.PartialSuccess("too old", 3)
+	// A full success on the secondary endpoint doesn't hide the failover.
+	r.PartialSuccess("", 0)
+	require.Len(t, rep.errs, 3)
+	for _, err := range rep.errs {
+		require.EqualError(t, err, "failed over")
+	}
+
+	r.PartialSuccess("too old", 3)
+	r.Failover(nil)
+	require.Len(t, rep.errs, 5)
+	require.EqualError(t, rep.errs[4], "partial success response, 3 items rejected: too old")
+
+	r.PartialSuccess("", 0)
+	require.Len(t, rep.errs, 6)
+	assert.NoError(t, rep.errs[5])
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package failover selects the endpoint to export to among ordered endpoints,
+// failing over to the next endpoint while the circuit of an endpoint is open.
+package failover // import "go.opentelemetry.io/collector/exporter/internal/failover"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// Circuits tracks a circuit breaker for each of the ordered endpoints, and selects
+// the first endpoint whose circuit is closed as the active endpoint.
+//
+// The circuit of an endpoint opens after a number of consecutive failed exports, and
+// stays open for a duration. Once this duration elapsed, the endpoint is active again
+// if it comes first, and its circuit opens again on the next failed export until an
+// export succeeds.
+//
+// A nil *Circuits always selects the first endpoint.
+type Circuits struct {
+	consecutiveFailures int
+	openDuration        time.Duration
+	onChange            func(active int)
+
+	mu       sync.Mutex
+	circuits []circuit
+	active   int
+}
+
+type circuit struct {
+	failures  int
+	openUntil time.Time
+}
+
+// New returns the circuits of n endpoints, opened after consecutiveFailures failed
+// exports for openDuration. onChange is called with the index of the active endpoint
+// whenever it changes.
+func New(n, consecutiveFailures int, openDuration time.Duration, onChange func(active int)) *Circuits {
+	return &Circuits{
+		consecutiveFailures: max(consecutiveFailures, 1),
+		openDuration:        openDuration,
+		onChange:            onChange,
+		circuits:            make([]circuit, n),
+	}
+}
+
+// Active returns the index of the endpoint to export to: the first one whose circuit
+// is closed or, when all of them are open, the one whose circuit closes first.
+func (c *Circuits) Active() int {
+	if c == nil {
+		return 0
+	}
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	active := -1
+	for i := range c.circuits {
+		if !now.Before(c.circuits[i].openUntil) {
+			active = i
+			break
+		}
+	}
+	if active < 0 {
+		active = 0
+		for i := range c.circuits {
+			if c.circuits[i].openUntil.Before(c.circuits[active].openUntil) {
+				active = i
+			}
+		}
+	}
+	if active != c.active {
+		c.active = active
+		if c.onChange != nil {
+			c.onChange(active)
+		}
+	}
+	return active
+}
+
+// Record records the result of an export to the endpoint i. Permanent errors are
+// caused by the exported data rather than by the endpoint, and are not recorded.
+func (c *Circuits) Record(i int, err error) {
+	if c == nil || consumererror.IsPermanent(err) {
+		return
+	}
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ci := &c.circuits[i]
+	if err == nil {
+		ci.failures = 0
+		return
+	}
+	ci.failures++
+	// The failures of the exports sent before the circuit opened don't extend it.
+	if ci.failures >= c.consecutiveFailures && !now.Before(ci.openUntil) {
+		ci.openUntil = now.Add(c.openDuration)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failover
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestCircuitsFailover(t *testing.T) {
+	var changes []int
+	c := New(3, 2, time.Hour, func(active int) {
+		changes = append(changes, active)
+	})
+	errUnavailable := errors.New("unavailable")
+
+	assert.Equal(t, 0, c.Active())
+	c.Record(0, errUnavailable)
+	assert.Equal(t, 0, c.Active())
+	// A success resets the consecutive failures.
+	c.Record(0, nil)
+	c.Record(0, errUnavailable)
+	assert.Equal(t, 0, c.Active())
+	// Permanent errors are not recorded.
+	c.Record(0, consumererror.NewPermanent(errUnavailable))
+	assert.Equal(t, 0, c.Active())
+	assert.Empty(t, changes)
+
+	c.Record(0, errUnavailable)
+	assert.Equal(t, 1, c.Active())
+	c.Record(1, errUnavailable)
+	c.Record(1, errUnavailable)
+	assert.Equal(t, 2, c.Active())
+	assert.Equal(t, []int{1, 2}, changes)
+
+	// The endpoint whose circuit closes first is used when all of them are open.
+	c.Record(2, errUnavailable)
+	c.Record(2, errUnavailable)
+	assert.Equal(t, 0, c.Active())
+	assert.Equal(t, []int{1, 2, 0}, changes)
+}
+
+func TestCircuitsReopen(t *testing.T) {
+	c := New(2, 2, 50*time.Millisecond, nil)
+	errUnavailable := errors.New("unavailable")
+
+	c.Record(0, errUnavailable)
+	c.Record(0, errUnavailable)
+	assert.Equal(t, 1, c.Active())
+	assert.Eventually(t, func() bool {
+		return c.Active() == 0
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The circuit opens again on the first failure until an export succeeds.
+	c.Record(0, errUnavailable)
+	assert.Equal(t, 1, c.Active())
+	assert.Eventually(t, func() bool {
+		return c.Active() == 0
+	}, 10*time.Second, 10*time.Millisecond)
+	c.Record(0, nil)
+	c.Record(0, errUnavailable)
+	assert.Equal(t, 0, c.Active())
+}
+
+func TestNilCircuits(t *testing.T) {
+	var c *Circuits
+	c.Record(0, errors.New("unavailable"))
+	assert.Equal(t, 0, c.Active())
+}
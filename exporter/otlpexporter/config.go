@@ -13,6 +13,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -29,10 +30,36 @@ type Config struct {
 	BatcherConfig exporterbatcher.Config `mapstructure:"batcher"`
 
 	configgrpc.ClientConfig `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// ReportPartialSuccessStatus reports partial success responses as recoverable errors
+	// through component status, until the next response without partial success.
+	ReportPartialSuccessStatus bool `mapstructure:"report_partial_success_status"`
+
+	// Failover configures the failover to secondary endpoints when the endpoint fails.
+	Failover *FailoverConfig `mapstructure:"failover"`
 }
 
 func (c *Config) Validate() error {
-	endpoint := c.sanitizedEndpoint()
+	if c.LoadBalancing != nil {
+		if c.Failover != nil {
+			return errors.New(`"failover" cannot be used with "load_balancing"`)
+		}
+		// The endpoints to balance between replace the endpoint.
+		return nil
+	}
+
+	return validateEndpoint(c.Endpoint)
+}
+
+func validateEndpoint(rawEndpoint string) error {
+	if path, ok := confignet.UnixSocketPath(rawEndpoint); ok {
+		if path == "" {
+			return errors.New(`requires the path of the socket in a "unix://" endpoint`)
+		}
+		return nil
+	}
+
+	endpoint := sanitizeEndpoint(rawEndpoint)
 	if endpoint == "" {
 		return errors.New(`requires a non-empty "endpoint"`)
 	}
@@ -50,16 +77,20 @@ func (c *Config) Validate() error {
 }
 
 func (c *Config) sanitizedEndpoint() string {
+	return sanitizeEndpoint(c.Endpoint)
+}
+
+func sanitizeEndpoint(endpoint string) string {
 	switch {
-	case strings.HasPrefix(c.Endpoint, "http://"):
-		return strings.TrimPrefix(c.Endpoint, "http://")
-	case strings.HasPrefix(c.Endpoint, "https://"):
-		return strings.TrimPrefix(c.Endpoint, "https://")
-	case strings.HasPrefix(c.Endpoint, "dns://"):
+	case strings.HasPrefix(endpoint, "http://"):
+		return strings.TrimPrefix(endpoint, "http://")
+	case strings.HasPrefix(endpoint, "https://"):
+		return strings.TrimPrefix(endpoint, "https://")
+	case strings.HasPrefix(endpoint, "dns://"):
 		r := regexp.MustCompile("^dns://[/]?")
-		return r.ReplaceAllString(c.Endpoint, "")
+		return r.ReplaceAllString(endpoint, "")
 	default:
-		return c.Endpoint
+		return endpoint
 	}
 }
 
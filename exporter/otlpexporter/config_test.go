@@ -130,6 +130,22 @@ func TestUnmarshalInvalidConfig(t *testing.T) {
 			name:     "invalid_port",
 			errorMsg: `invalid port "port"`,
 		},
+		{
+			name:     "unix_endpoint_without_path",
+			errorMsg: `requires the path of the socket in a "unix://" endpoint`,
+		},
+		{
+			name:     "invalid_load_balancing_policy",
+			errorMsg: `invalid load balancing policy: "random"`,
+		},
+		{
+			name:     "invalid_failover_endpoint",
+			errorMsg: `invalid failover endpoint "backend-2": address backend-2: missing port in address`,
+		},
+		{
+			name:     "failover_with_load_balancing",
+			errorMsg: `"failover" cannot be used with "load_balancing"`,
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := factory.CreateDefaultConfig()
@@ -141,6 +157,15 @@ func TestUnmarshalInvalidConfig(t *testing.T) {
 	}
 }
 
+func TestValidLoadBalancingWithoutEndpoint(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.LoadBalancing = &configgrpc.LoadBalancingConfig{
+		Endpoints: []string{"backend-1:4317", "backend-2:4317"},
+	}
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
 func TestValidDNSEndpoint(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
@@ -148,6 +173,13 @@ func TestValidDNSEndpoint(t *testing.T) {
 	assert.NoError(t, cfg.Validate())
 }
 
+func TestValidUnixEndpoint(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = "unix:///var/run/otelcol/otlp.sock"
+	assert.NoError(t, cfg.Validate())
+}
+
 func TestSanitizeEndpoint(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
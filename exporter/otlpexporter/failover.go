@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/exporter/internal/exportstatus"
+	"go.opentelemetry.io/collector/exporter/internal/failover"
+)
+
+const (
+	defaultFailoverConsecutiveFailures = 3
+	defaultFailoverOpenDuration        = time.Minute
+)
+
+// FailoverConfig configures the failover to secondary endpoints.
+type FailoverConfig struct {
+	// Endpoints are the secondary endpoints, in order of priority. The data is sent to
+	// the first endpoint, starting with the primary endpoint, whose circuit is closed.
+	// They share the other settings of the primary endpoint.
+	Endpoints []string `mapstructure:"endpoints"`
+
+	// ConsecutiveFailures is the number of consecutive failed exports, retries included,
+	// after which the circuit of an endpoint opens. Default is 3.
+	ConsecutiveFailures int `mapstructure:"consecutive_failures"`
+
+	// OpenDuration is how long the circuit of an endpoint stays open before the data is
+	// sent to it again. Default is 1m.
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+}
+
+// Validate checks if the failover configuration is valid.
+func (cfg *FailoverConfig) Validate() error {
+	var errs error
+	if len(cfg.Endpoints) == 0 {
+		errs = errors.Join(errs, errors.New("failover requires at least one endpoint"))
+	}
+	for _, endpoint := range cfg.Endpoints {
+		if err := validateEndpoint(endpoint); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid failover endpoint %q: %w", endpoint, err))
+		}
+	}
+	if cfg.ConsecutiveFailures < 0 {
+		errs = errors.Join(errs, errors.New("failover consecutive_failures must be non-negative"))
+	}
+	if cfg.OpenDuration < 0 {
+		errs = errors.Join(errs, errors.New("failover open_duration must be non-negative"))
+	}
+	return errs
+}
+
+// newFailoverCircuits returns the circuits of the primary and secondary endpoints,
+// logging the changes of the active endpoint and reporting them to status: an error
+// while a secondary endpoint is active, which status reports until the primary
+// endpoint is active again.
+func newFailoverCircuits(cfg *FailoverConfig, primary string, status *exportstatus.Reporter, logger *zap.Logger) *failover.Circuits {
+	consecutiveFailures := cfg.ConsecutiveFailures
+	if consecutiveFailures == 0 {
+		consecutiveFailures = defaultFailoverConsecutiveFailures
+	}
+	openDuration := cfg.OpenDuration
+	if openDuration == 0 {
+		openDuration = defaultFailoverOpenDuration
+	}
+	endpoints := append([]string{primary}, cfg.Endpoints...)
+	return failover.New(len(endpoints), consecutiveFailures, openDuration, func(active int) {
+		if active == 0 {
+			logger.Info("Exporting to the primary endpoint again", zap.String("endpoint", primary))
+			status.Failover(nil)
+			return
+		}
+		logger.Warn("Failing over to a secondary endpoint", zap.String("endpoint", endpoints[active]))
+		status.Failover(fmt.Errorf("failed over to secondary endpoint %q", endpoints[active]))
+	})
+}
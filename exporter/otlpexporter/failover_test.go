@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpexporter
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+type statusHost struct {
+	component.Host
+	events []*componentstatus.Event
+}
+
+func (h *statusHost) Report(e *componentstatus.Event) {
+	h.events = append(h.events, e)
+}
+
+func TestFailoverConfigValidate(t *testing.T) {
+	cfg := &FailoverConfig{Endpoints: []string{"backend-2:4317", "dns:///backend-3:4317"}}
+	require.NoError(t, cfg.Validate())
+
+	cfg = &FailoverConfig{ConsecutiveFailures: -1, OpenDuration: -time.Second}
+	assert.EqualError(t, cfg.Validate(), "failover requires at least one endpoint\n"+
+		"failover consecutive_failures must be non-negative\nfailover open_duration must be non-negative")
+}
+
+func TestSendTracesFailover(t *testing.T) {
+	receivers := make([]*mockTracesReceiver, 2)
+	endpoints := make([]string, 2)
+	for i := range receivers {
+		ln, err := net.Listen("tcp", "localhost:")
+		require.NoError(t, err)
+		receivers[i], err = otlpTracesReceiverOnGRPCServer(ln, false)
+		require.NoError(t, err)
+		defer receivers[i].srv.GracefulStop()
+		endpoints[i] = ln.Addr().String()
+	}
+	receivers[0].setExportError(status.Error(codes.Unavailable, "unavailable"))
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.QueueConfig.Enabled = false
+	cfg.RetryConfig.Enabled = false
+	cfg.Endpoint = endpoints[0]
+	cfg.TLSSetting = configtls.ClientConfig{Insecure: true}
+	cfg.Failover = &FailoverConfig{
+		Endpoints:           endpoints[1:],
+		ConsecutiveFailures: 2,
+		OpenDuration:        100 * time.Millisecond,
+	}
+	require.NoError(t, cfg.Validate())
+	exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, exp.Start(context.Background(), host))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	td := testdata.GenerateTraces(2)
+	// The traces are sent to the secondary endpoint after 2 failures of the primary endpoint.
+	require.Error(t, exp.ConsumeTraces(context.Background(), td))
+	require.Error(t, exp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, int64(2), receivers[0].requestCount.Load())
+	assert.Equal(t, int64(1), receivers[1].requestCount.Load())
+	require.Len(t, host.events, 1)
+	assert.Equal(t, componentstatus.StatusRecoverableError, host.events[0].Status())
+	require.EqualError(t, host.events[0].Err(), `failed over to secondary endpoint "`+endpoints[1]+`"`)
+
+	// The traces are sent to the primary endpoint again once its circuit closes.
+	receivers[0].setExportError(nil)
+	require.Eventually(t, func() bool {
+		assert.NoError(t, exp.ConsumeTraces(context.Background(), td))
+		return receivers[0].requestCount.Load() == 3
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Len(t, host.events, 2)
+	assert.Equal(t, componentstatus.StatusOK, host.events[1].Status())
+}
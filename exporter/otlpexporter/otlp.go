@@ -5,6 +5,7 @@ package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexpor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"time"
@@ -17,10 +18,13 @@ import (
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/internal/exportstatus"
+	"go.opentelemetry.io/collector/exporter/internal/failover"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -35,19 +39,41 @@ type baseExporter struct {
 	// Input configuration.
 	config *Config
 
-	// gRPC clients and connection.
+	// gRPC clients and connection of the primary endpoint, followed by the ones
+	// of the failover endpoints.
+	clients     []*grpcClients
+	metadata    metadata.MD
+	callOptions []grpc.CallOption
+
+	// failover is set when failing over to secondary endpoints.
+	failover *failover.Circuits
+
+	settings component.TelemetrySettings
+
+	// status reports partial success responses, if enabled, and failovers through component status.
+	status *exportstatus.Reporter
+
+	// Default user-agent header.
+	userAgent string
+}
+
+// grpcClients are the gRPC clients and connection of an endpoint.
+type grpcClients struct {
 	traceExporter   ptraceotlp.GRPCClient
 	metricExporter  pmetricotlp.GRPCClient
 	logExporter     plogotlp.GRPCClient
 	profileExporter pprofileotlp.GRPCClient
 	clientConn      *grpc.ClientConn
-	metadata        metadata.MD
-	callOptions     []grpc.CallOption
-
-	settings component.TelemetrySettings
+}
 
-	// Default user-agent header.
-	userAgent string
+func newGRPCClients(clientConn *grpc.ClientConn) *grpcClients {
+	return &grpcClients{
+		traceExporter:   ptraceotlp.NewGRPCClient(clientConn),
+		metricExporter:  pmetricotlp.NewGRPCClient(clientConn),
+		logExporter:     plogotlp.NewGRPCClient(clientConn),
+		profileExporter: pprofileotlp.NewGRPCClient(clientConn),
+		clientConn:      clientConn,
+	}
 }
 
 func newExporter(cfg component.Config, set exporter.Settings) *baseExporter {
@@ -63,13 +89,29 @@ func newExporter(cfg component.Config, set exporter.Settings) *baseExporter {
 // is the only place we get hold of Extensions which are required to construct auth round tripper.
 func (e *baseExporter) start(ctx context.Context, host component.Host) (err error) {
 	agentOpt := configgrpc.WithGrpcDialOption(grpc.WithUserAgent(e.userAgent))
-	if e.clientConn, err = e.config.ClientConfig.ToClientConn(ctx, host, e.settings, agentOpt); err != nil {
+	clientConn, err := e.config.ClientConfig.ToClientConn(ctx, host, e.settings, agentOpt)
+	if err != nil {
 		return err
 	}
-	e.traceExporter = ptraceotlp.NewGRPCClient(e.clientConn)
-	e.metricExporter = pmetricotlp.NewGRPCClient(e.clientConn)
-	e.logExporter = plogotlp.NewGRPCClient(e.clientConn)
-	e.profileExporter = pprofileotlp.NewGRPCClient(e.clientConn)
+	e.clients = []*grpcClients{newGRPCClients(clientConn)}
+	e.status = exportstatus.New(e.config.ReportPartialSuccessStatus, func(err error) {
+		if err == nil {
+			componentstatus.ReportStatus(host, componentstatus.NewEvent(componentstatus.StatusOK))
+			return
+		}
+		componentstatus.ReportStatus(host, componentstatus.NewRecoverableErrorEvent(err))
+	})
+	if e.config.Failover != nil {
+		for _, endpoint := range e.config.Failover.Endpoints {
+			clientCfg := e.config.ClientConfig
+			clientCfg.Endpoint = endpoint
+			if clientConn, err = clientCfg.ToClientConn(ctx, host, e.settings, agentOpt); err != nil {
+				return err
+			}
+			e.clients = append(e.clients, newGRPCClients(clientConn))
+		}
+		e.failover = newFailoverCircuits(e.config.Failover, e.config.Endpoint, e.status, e.settings.Logger)
+	}
 	headers := map[string]string{}
 	for k, v := range e.config.ClientConfig.Headers {
 		headers[k] = string(v)
@@ -78,21 +120,24 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) (err erro
 	e.callOptions = []grpc.CallOption{
 		grpc.WaitForReady(e.config.ClientConfig.WaitForReady),
 	}
-
 	return
 }
 
 func (e *baseExporter) shutdown(context.Context) error {
-	if e.clientConn != nil {
-		return e.clientConn.Close()
+	var errs error
+	for _, clients := range e.clients {
+		errs = errors.Join(errs, clients.clientConn.Close())
 	}
-	return nil
+	return errs
 }
 
 func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	req := ptraceotlp.NewExportRequestFromTraces(td)
-	resp, respErr := e.traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
+	active := e.failover.Active()
+	resp, respErr := e.clients[active].traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	err := processError(respErr)
+	e.failover.Record(active, err)
+	if err != nil {
 		return err
 	}
 	partialSuccess := resp.PartialSuccess()
@@ -102,13 +147,18 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 			zap.Int64("dropped_spans", resp.PartialSuccess().RejectedSpans()),
 		)
 	}
+	exporterhelper.RecordRejectedItems(ctx, partialSuccess.RejectedSpans())
+	e.status.PartialSuccess(partialSuccess.ErrorMessage(), partialSuccess.RejectedSpans())
 	return nil
 }
 
 func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
 	req := pmetricotlp.NewExportRequestFromMetrics(md)
-	resp, respErr := e.metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
+	active := e.failover.Active()
+	resp, respErr := e.clients[active].metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	err := processError(respErr)
+	e.failover.Record(active, err)
+	if err != nil {
 		return err
 	}
 	partialSuccess := resp.PartialSuccess()
@@ -118,13 +168,18 @@ func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) erro
 			zap.Int64("dropped_data_points", resp.PartialSuccess().RejectedDataPoints()),
 		)
 	}
+	exporterhelper.RecordRejectedItems(ctx, partialSuccess.RejectedDataPoints())
+	e.status.PartialSuccess(partialSuccess.ErrorMessage(), partialSuccess.RejectedDataPoints())
 	return nil
 }
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 	req := plogotlp.NewExportRequestFromLogs(ld)
-	resp, respErr := e.logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
+	active := e.failover.Active()
+	resp, respErr := e.clients[active].logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	err := processError(respErr)
+	e.failover.Record(active, err)
+	if err != nil {
 		return err
 	}
 	partialSuccess := resp.PartialSuccess()
@@ -134,13 +189,18 @@ func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 			zap.Int64("dropped_log_records", resp.PartialSuccess().RejectedLogRecords()),
 		)
 	}
+	exporterhelper.RecordRejectedItems(ctx, partialSuccess.RejectedLogRecords())
+	e.status.PartialSuccess(partialSuccess.ErrorMessage(), partialSuccess.RejectedLogRecords())
 	return nil
 }
 
 func (e *baseExporter) pushProfiles(ctx context.Context, td pprofile.Profiles) error {
 	req := pprofileotlp.NewExportRequestFromProfiles(td)
-	resp, respErr := e.profileExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
+	active := e.failover.Active()
+	resp, respErr := e.clients[active].profileExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	err := processError(respErr)
+	e.failover.Record(active, err)
+	if err != nil {
 		return err
 	}
 	partialSuccess := resp.PartialSuccess()
@@ -150,6 +210,8 @@ func (e *baseExporter) pushProfiles(ctx context.Context, td pprofile.Profiles) e
 			zap.Int64("dropped_profiles", resp.PartialSuccess().RejectedProfiles()),
 		)
 	}
+	exporterhelper.RecordRejectedItems(ctx, partialSuccess.RejectedProfiles())
+	e.status.PartialSuccess(partialSuccess.ErrorMessage(), partialSuccess.RejectedProfiles())
 	return nil
 }
 
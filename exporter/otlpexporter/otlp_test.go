@@ -25,6 +25,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configopaque"
@@ -462,6 +463,38 @@ func TestSendTracesWhenEndpointHasHttpScheme(t *testing.T) {
 	}
 }
 
+func TestSendTracesOnUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	// Start an OTLP-compatible receiver on a Unix domain socket.
+	socket := filepath.Join(t.TempDir(), "otlp.sock")
+	ln, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	rcv, err := otlpTracesReceiverOnGRPCServer(ln, false)
+	require.NoError(t, err, "Failed to start mock OTLP receiver")
+	// Also closes the connection.
+	defer rcv.srv.GracefulStop()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = "unix://" + socket
+	cfg.ClientConfig.TLSSetting = configtls.ClientConfig{Insecure: true}
+	require.NoError(t, component.ValidateConfig(cfg))
+	exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.Eventually(t, func() bool {
+		return rcv.requestCount.Load() > 0
+	}, 10*time.Second, 5*time.Millisecond)
+	assert.EqualValues(t, 2, rcv.totalItems.Load())
+}
+
 func TestSendMetrics(t *testing.T) {
 	// Start an OTLP-compatible receiver.
 	ln, err := net.Listen("tcp", "localhost:")
@@ -10,6 +10,8 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confighttp/xconfighttp"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
@@ -60,6 +62,17 @@ type Config struct {
 
 	// The encoding to export telemetry (default: "proto")
 	Encoding EncodingType `mapstructure:"encoding"`
+
+	// ReportPartialSuccessStatus reports partial success responses as recoverable errors
+	// through component status, until the next response without partial success.
+	ReportPartialSuccessStatus bool `mapstructure:"report_partial_success_status"`
+
+	// Failover configures the failover to secondary endpoints when the endpoint fails.
+	Failover *FailoverConfig `mapstructure:"failover"`
+
+	// HTTP3 configures the sending of the requests with HTTP/3 over QUIC. The settings
+	// of the HTTP/1.1 and HTTP/2 connections, and the proxy, don't apply to HTTP/3.
+	HTTP3 *xconfighttp.HTTP3Config `mapstructure:"http3"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -69,5 +82,11 @@ func (cfg *Config) Validate() error {
 	if cfg.Endpoint == "" && cfg.TracesEndpoint == "" && cfg.MetricsEndpoint == "" && cfg.LogsEndpoint == "" {
 		return errors.New("at least one endpoint must be specified")
 	}
+	if cfg.Failover != nil && cfg.LoadBalancing != nil {
+		return errors.New(`"failover" cannot be used with "load_balancing"`)
+	}
+	if _, isUnix := confignet.UnixSocketPath(cfg.Endpoint); isUnix && cfg.Failover != nil {
+		return errors.New(`"failover" cannot be used with a "unix://" endpoint`)
+	}
 	return nil
 }
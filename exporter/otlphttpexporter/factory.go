@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
@@ -66,13 +67,25 @@ func composeSignalURL(oCfg *Config, signalOverrideURL string, signalName string,
 	case oCfg.Endpoint == "":
 		return "", fmt.Errorf("either endpoint or %s_endpoint must be specified", signalName)
 	default:
-		if strings.HasSuffix(oCfg.Endpoint, "/") {
-			return oCfg.Endpoint + signalVersion + "/" + signalName, nil
+		if _, isUnix := confignet.UnixSocketPath(oCfg.Endpoint); isUnix {
+			return signalURL(unixSocketBaseURL, signalName, signalVersion), nil
 		}
-		return oCfg.Endpoint + "/" + signalVersion + "/" + signalName, nil
+		return signalURL(oCfg.Endpoint, signalName, signalVersion), nil
 	}
 }
 
+// unixSocketBaseURL is the base URL of the requests to a "unix://" endpoint. The client
+// sends them through the Unix domain socket of the endpoint whatever their URL.
+const unixSocketBaseURL = "http://localhost"
+
+// signalURL appends the path of the signal to the base URL of an endpoint.
+func signalURL(endpoint string, signalName string, signalVersion string) string {
+	if strings.HasSuffix(endpoint, "/") {
+		return endpoint + signalVersion + "/" + signalName
+	}
+	return endpoint + "/" + signalVersion + "/" + signalName
+}
+
 func createTraces(
 	ctx context.Context,
 	set exporter.Settings,
@@ -88,10 +101,12 @@ func createTraces(
 	if err != nil {
 		return nil, err
 	}
+	oce.failoverURLs = failoverSignalURLs(oCfg.Failover, "traces", "v1")
 
 	return exporterhelper.NewTraces(ctx, set, cfg,
 		oce.pushTraces,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
@@ -114,10 +129,12 @@ func createMetrics(
 	if err != nil {
 		return nil, err
 	}
+	oce.failoverURLs = failoverSignalURLs(oCfg.Failover, "metrics", "v1")
 
 	return exporterhelper.NewMetrics(ctx, set, cfg,
 		oce.pushMetrics,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
@@ -139,10 +156,12 @@ func createLogs(
 	if err != nil {
 		return nil, err
 	}
+	oce.failoverURLs = failoverSignalURLs(oCfg.Failover, "logs", "v1")
 
 	return exporterhelper.NewLogs(ctx, set, cfg,
 		oce.pushLogs,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
@@ -165,10 +184,12 @@ func createProfiles(
 	if err != nil {
 		return nil, err
 	}
+	oce.failoverURLs = failoverSignalURLs(oCfg.Failover, "profiles", "v1development")
 
 	return xexporterhelper.NewProfilesExporter(ctx, set, cfg,
 		oce.pushProfiles,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
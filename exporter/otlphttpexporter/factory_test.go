@@ -231,4 +231,10 @@ func TestComposeSignalURL(t *testing.T) {
 	url, err = composeSignalURL(cfg, "", "traces", "v2")
 	require.NoError(t, err)
 	assert.Equal(t, "http://localhost:4318/v2/traces", url)
+
+	// Unix domain socket
+	cfg.ClientConfig.Endpoint = "unix:///var/run/otelcol/otlp.sock"
+	url, err = composeSignalURL(cfg, "", "traces", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost/v1/traces", url)
 }
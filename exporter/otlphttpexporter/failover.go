@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter // import "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/exporter/internal/exportstatus"
+	"go.opentelemetry.io/collector/exporter/internal/failover"
+)
+
+const (
+	defaultFailoverConsecutiveFailures = 3
+	defaultFailoverOpenDuration        = time.Minute
+)
+
+// FailoverConfig configures the failover to secondary endpoints.
+type FailoverConfig struct {
+	// Endpoints are the base URLs of the secondary endpoints, in order of priority, to
+	// which the signal paths are appended as to Endpoint. The data is sent to the first
+	// endpoint, starting with the primary endpoint, whose circuit is closed. They share
+	// the other settings of the primary endpoint.
+	Endpoints []string `mapstructure:"endpoints"`
+
+	// ConsecutiveFailures is the number of consecutive failed exports, retries included,
+	// after which the circuit of an endpoint opens. Default is 3.
+	ConsecutiveFailures int `mapstructure:"consecutive_failures"`
+
+	// OpenDuration is how long the circuit of an endpoint stays open before the data is
+	// sent to it again. Default is 1m.
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+}
+
+// Validate checks if the failover configuration is valid.
+func (cfg *FailoverConfig) Validate() error {
+	var errs error
+	if len(cfg.Endpoints) == 0 {
+		errs = errors.Join(errs, errors.New("failover requires at least one endpoint"))
+	}
+	for _, endpoint := range cfg.Endpoints {
+		if endpoint == "" {
+			errs = errors.Join(errs, errors.New("failover endpoints must be non-empty"))
+		} else if _, isUnix := confignet.UnixSocketPath(endpoint); isUnix {
+			errs = errors.Join(errs, fmt.Errorf("invalid failover endpoint %q: unix endpoints are not supported", endpoint))
+		} else if _, err := url.Parse(endpoint); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid failover endpoint %q: %w", endpoint, err))
+		}
+	}
+	if cfg.ConsecutiveFailures < 0 {
+		errs = errors.Join(errs, errors.New("failover consecutive_failures must be non-negative"))
+	}
+	if cfg.OpenDuration < 0 {
+		errs = errors.Join(errs, errors.New("failover open_duration must be non-negative"))
+	}
+	return errs
+}
+
+// newFailoverCircuits returns the circuits of the primary and secondary endpoints,
+// logging the changes of the active endpoint and reporting them to status: an error
+// while a secondary endpoint is active, which status reports until the primary
+// endpoint is active again.
+func newFailoverCircuits(cfg *FailoverConfig, status *exportstatus.Reporter, logger *zap.Logger) *failover.Circuits {
+	consecutiveFailures := cfg.ConsecutiveFailures
+	if consecutiveFailures == 0 {
+		consecutiveFailures = defaultFailoverConsecutiveFailures
+	}
+	openDuration := cfg.OpenDuration
+	if openDuration == 0 {
+		openDuration = defaultFailoverOpenDuration
+	}
+	return failover.New(len(cfg.Endpoints)+1, consecutiveFailures, openDuration, func(active int) {
+		if active == 0 {
+			logger.Info("Exporting to the primary endpoint again")
+			status.Failover(nil)
+			return
+		}
+		endpoint := cfg.Endpoints[active-1]
+		logger.Warn("Failing over to a secondary endpoint", zap.String("endpoint", endpoint))
+		status.Failover(fmt.Errorf("failed over to secondary endpoint %q", endpoint))
+	})
+}
+
+// failoverSignalURLs returns the URLs of the signal on the secondary endpoints.
+func failoverSignalURLs(cfg *FailoverConfig, signalName string, signalVersion string) []string {
+	if cfg == nil {
+		return nil
+	}
+	urls := make([]string, 0, len(cfg.Endpoints))
+	for _, endpoint := range cfg.Endpoints {
+		urls = append(urls, signalURL(endpoint, signalName, signalVersion))
+	}
+	return urls
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+type statusHost struct {
+	component.Host
+	events []*componentstatus.Event
+}
+
+func (h *statusHost) Report(e *componentstatus.Event) {
+	h.events = append(h.events, e)
+}
+
+func TestFailoverConfigValidate(t *testing.T) {
+	cfg := &FailoverConfig{Endpoints: []string{"https://backend-2:4318"}}
+	require.NoError(t, cfg.Validate())
+
+	cfg = &FailoverConfig{Endpoints: []string{""}, ConsecutiveFailures: -1, OpenDuration: -time.Second}
+	assert.EqualError(t, cfg.Validate(), "failover endpoints must be non-empty\n"+
+		"failover consecutive_failures must be non-negative\nfailover open_duration must be non-negative")
+
+	oCfg := createDefaultConfig().(*Config)
+	oCfg.Endpoint = "https://backend-1:4318"
+	oCfg.LoadBalancing = &confighttp.LoadBalancingConfig{Endpoints: []string{"backend-1:4318"}}
+	oCfg.Failover = cfg
+	assert.EqualError(t, oCfg.Validate(), `"failover" cannot be used with "load_balancing"`)
+
+	oCfg.LoadBalancing = nil
+	oCfg.Endpoint = "unix:///var/run/otelcol/otlp.sock"
+	assert.EqualError(t, oCfg.Validate(), `"failover" cannot be used with a "unix://" endpoint`)
+
+	cfg = &FailoverConfig{Endpoints: []string{"unix:///var/run/otelcol/otlp.sock"}}
+	assert.EqualError(t, cfg.Validate(), `invalid failover endpoint "unix:///var/run/otelcol/otlp.sock": unix endpoints are not supported`)
+}
+
+func TestSendTracesFailover(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+	var requests [2]atomic.Int64
+	var paths [2]atomic.Value
+	servers := make([]*httptest.Server, 2)
+	for i := range servers {
+		servers[i] = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests[i].Add(1)
+			paths[i].Store(r.URL.Path)
+			if i == 0 && failing.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer servers[i].Close()
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.QueueConfig.Enabled = false
+	cfg.RetryConfig.Enabled = false
+	cfg.Endpoint = servers[0].URL
+	cfg.Failover = &FailoverConfig{
+		Endpoints:           []string{servers[1].URL},
+		ConsecutiveFailures: 2,
+		OpenDuration:        100 * time.Millisecond,
+	}
+	require.NoError(t, cfg.Validate())
+	exp, err := NewFactory().CreateTraces(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, exp.Start(context.Background(), host))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	td := testdata.GenerateTraces(2)
+	// The traces are sent to the secondary endpoint after 2 failures of the primary endpoint.
+	require.Error(t, exp.ConsumeTraces(context.Background(), td))
+	require.Error(t, exp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, int64(2), requests[0].Load())
+	assert.Equal(t, int64(1), requests[1].Load())
+	assert.Equal(t, "/v1/traces", paths[1].Load())
+	require.Len(t, host.events, 1)
+	assert.Equal(t, componentstatus.StatusRecoverableError, host.events[0].Status())
+	require.EqualError(t, host.events[0].Err(), `failed over to secondary endpoint "`+servers[1].URL+`"`)
+
+	// The traces are sent to the primary endpoint again once its circuit closes.
+	failing.Store(false)
+	require.Eventually(t, func() bool {
+		assert.NoError(t, exp.ConsumeTraces(context.Background(), td))
+		return requests[0].Load() == 3
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Len(t, host.events, 2)
+	assert.Equal(t, componentstatus.StatusOK, host.events[1].Status())
+}
@@ -20,9 +20,12 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/internal/exportstatus"
+	"go.opentelemetry.io/collector/exporter/internal/failover"
 	"go.opentelemetry.io/collector/internal/httphelper"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
@@ -36,14 +39,22 @@ import (
 
 type baseExporter struct {
 	// Input configuration.
-	config      *Config
-	client      *http.Client
+	config *Config
+	client *http.Client
+	// http3Closer closes the QUIC connections of client, nil if HTTP/3 is not enabled.
+	http3Closer io.Closer
 	tracesURL   string
 	metricsURL  string
 	logsURL     string
 	profilesURL string
-	logger      *zap.Logger
-	settings    component.TelemetrySettings
+	// failoverURLs are the URLs of the signal on the secondary endpoints.
+	failoverURLs []string
+	logger       *zap.Logger
+	settings     component.TelemetrySettings
+	// status reports partial success responses, if enabled, and failovers through component status.
+	status *exportstatus.Reporter
+	// failover is set when failing over to secondary endpoints.
+	failover *failover.Circuits
 	// Default user-agent header.
 	userAgent string
 }
@@ -82,11 +93,34 @@ func newExporter(cfg component.Config, set exporter.Settings) (*baseExporter, er
 // start actually creates the HTTP client. The client construction is deferred till this point as this
 // is the only place we get hold of Extensions which are required to construct auth round tripper.
 func (e *baseExporter) start(ctx context.Context, host component.Host) error {
-	client, err := e.config.ClientConfig.ToClient(ctx, host, e.settings)
+	var client *http.Client
+	var err error
+	if e.config.HTTP3 != nil && e.config.HTTP3.Enabled {
+		client, e.http3Closer, err = e.config.HTTP3.ToClient(ctx, &e.config.ClientConfig, host, e.settings)
+	} else {
+		client, err = e.config.ClientConfig.ToClient(ctx, host, e.settings)
+	}
 	if err != nil {
 		return err
 	}
 	e.client = client
+	e.status = exportstatus.New(e.config.ReportPartialSuccessStatus, func(err error) {
+		if err == nil {
+			componentstatus.ReportStatus(host, componentstatus.NewEvent(componentstatus.StatusOK))
+			return
+		}
+		componentstatus.ReportStatus(host, componentstatus.NewRecoverableErrorEvent(err))
+	})
+	if e.config.Failover != nil {
+		e.failover = newFailoverCircuits(e.config.Failover, e.status, e.logger)
+	}
+	return nil
+}
+
+func (e *baseExporter) shutdown(context.Context) error {
+	if e.http3Closer != nil {
+		return e.http3Closer.Close()
+	}
 	return nil
 }
 
@@ -173,7 +207,18 @@ func (e *baseExporter) pushProfiles(ctx context.Context, td pprofile.Profiles) e
 	return e.export(ctx, e.profilesURL, request, e.profilesPartialSuccessHandler)
 }
 
+// export sends the request to the URL of the signal on the active endpoint.
 func (e *baseExporter) export(ctx context.Context, url string, request []byte, partialSuccessHandler partialSuccessHandler) error {
+	active := e.failover.Active()
+	if active > 0 {
+		url = e.failoverURLs[active-1]
+	}
+	err := e.send(ctx, url, request, partialSuccessHandler)
+	e.failover.Record(active, err)
+	return err
+}
+
+func (e *baseExporter) send(ctx context.Context, url string, request []byte, partialSuccessHandler partialSuccessHandler) error {
 	e.logger.Debug("Preparing to make HTTP request", zap.String("url", url))
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(request))
 	if err != nil {
@@ -203,7 +248,7 @@ func (e *baseExporter) export(ctx context.Context, url string, request []byte, p
 	}()
 
 	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		return handlePartialSuccessResponse(resp, partialSuccessHandler)
+		return handlePartialSuccessResponse(ctx, resp, partialSuccessHandler)
 	}
 
 	respStatus := readResponseStatus(resp)
@@ -316,18 +361,18 @@ func readResponseStatus(resp *http.Response) *status.Status {
 	return respStatus
 }
 
-func handlePartialSuccessResponse(resp *http.Response, partialSuccessHandler partialSuccessHandler) error {
+func handlePartialSuccessResponse(ctx context.Context, resp *http.Response, partialSuccessHandler partialSuccessHandler) error {
 	bodyBytes, err := readResponseBody(resp)
 	if err != nil {
 		return err
 	}
 
-	return partialSuccessHandler(bodyBytes, resp.Header.Get("Content-Type"))
+	return partialSuccessHandler(ctx, bodyBytes, resp.Header.Get("Content-Type"))
 }
 
-type partialSuccessHandler func(bytes []byte, contentType string) error
+type partialSuccessHandler func(ctx context.Context, bytes []byte, contentType string) error
 
-func (e *baseExporter) tracesPartialSuccessHandler(protoBytes []byte, contentType string) error {
+func (e *baseExporter) tracesPartialSuccessHandler(ctx context.Context, protoBytes []byte, contentType string) error {
 	if protoBytes == nil {
 		return nil
 	}
@@ -354,10 +399,12 @@ func (e *baseExporter) tracesPartialSuccessHandler(protoBytes []byte, contentTyp
 			zap.Int64("dropped_spans", exportResponse.PartialSuccess().RejectedSpans()),
 		)
 	}
+	exporterhelper.RecordRejectedItems(ctx, partialSuccess.RejectedSpans())
+	e.status.PartialSuccess(partialSuccess.ErrorMessage(), partialSuccess.RejectedSpans())
 	return nil
 }
 
-func (e *baseExporter) metricsPartialSuccessHandler(protoBytes []byte, contentType string) error {
+func (e *baseExporter) metricsPartialSuccessHandler(ctx context.Context, protoBytes []byte, contentType string) error {
 	if protoBytes == nil {
 		return nil
 	}
@@ -384,10 +431,12 @@ func (e *baseExporter) metricsPartialSuccessHandler(protoBytes []byte, contentTy
 			zap.Int64("dropped_data_points", exportResponse.PartialSuccess().RejectedDataPoints()),
 		)
 	}
+	exporterhelper.RecordRejectedItems(ctx, partialSuccess.RejectedDataPoints())
+	e.status.PartialSuccess(partialSuccess.ErrorMessage(), partialSuccess.RejectedDataPoints())
 	return nil
 }
 
-func (e *baseExporter) logsPartialSuccessHandler(protoBytes []byte, contentType string) error {
+func (e *baseExporter) logsPartialSuccessHandler(ctx context.Context, protoBytes []byte, contentType string) error {
 	if protoBytes == nil {
 		return nil
 	}
@@ -414,10 +463,12 @@ func (e *baseExporter) logsPartialSuccessHandler(protoBytes []byte, contentType
 			zap.Int64("dropped_log_records", exportResponse.PartialSuccess().RejectedLogRecords()),
 		)
 	}
+	exporterhelper.RecordRejectedItems(ctx, partialSuccess.RejectedLogRecords())
+	e.status.PartialSuccess(partialSuccess.ErrorMessage(), partialSuccess.RejectedLogRecords())
 	return nil
 }
 
-func (e *baseExporter) profilesPartialSuccessHandler(protoBytes []byte, contentType string) error {
+func (e *baseExporter) profilesPartialSuccessHandler(ctx context.Context, protoBytes []byte, contentType string) error {
 	if protoBytes == nil {
 		return nil
 	}
@@ -444,5 +495,7 @@ func (e *baseExporter) profilesPartialSuccessHandler(protoBytes []byte, contentT
 			zap.Int64("dropped_samples", exportResponse.PartialSuccess().RejectedProfiles()),
 		)
 	}
+	exporterhelper.RecordRejectedItems(ctx, partialSuccess.RejectedProfiles())
+	e.status.PartialSuccess(partialSuccess.ErrorMessage(), partialSuccess.RejectedProfiles())
 	return nil
 }
@@ -9,9 +9,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -25,7 +29,9 @@ import (
 
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confighttp/xconfighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exportertest"
@@ -37,6 +43,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
 const (
@@ -265,6 +272,91 @@ func TestErrorResponseInvalidResponseBody(t *testing.T) {
 	assert.Nil(t, status)
 }
 
+func TestSendTracesOnUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	socket := filepath.Join(t.TempDir(), "otlp.sock")
+	ln, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	var received atomic.Int64
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/traces", func(writer http.ResponseWriter, request *http.Request) {
+		body, errRead := io.ReadAll(request.Body)
+		assert.NoError(t, errRead)
+		req := ptraceotlp.NewExportRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		received.Add(int64(req.Traces().SpanCount()))
+		writer.WriteHeader(http.StatusOK)
+	})
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: time.Second}
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer func() { assert.NoError(t, srv.Close()) }()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = "unix://" + socket
+	cfg.ClientConfig.Compression = ""
+	cfg.QueueConfig.Enabled = false
+	exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.Shutdown(context.Background())) }()
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.EqualValues(t, 2, received.Load())
+}
+
+func TestSendTracesWithHTTP3(t *testing.T) {
+	hss := &confighttp.ServerConfig{
+		Endpoint: "localhost:0",
+		TLSSetting: &configtls.ServerConfig{
+			Config: configtls.Config{
+				CertFile: filepath.Join("testdata", "server.crt"),
+				KeyFile:  filepath.Join("testdata", "server.key"),
+			},
+		},
+	}
+	http3Cfg := &xconfighttp.HTTP3Config{Enabled: true}
+	ln, err := http3Cfg.ToQUICListener(context.Background(), hss)
+	require.NoError(t, err)
+	var received atomic.Int64
+	srv := xconfighttp.NewHTTP3Server(&http.Server{Handler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "HTTP/3.0", request.Proto)
+		body, errRead := io.ReadAll(request.Body)
+		assert.NoError(t, errRead)
+		req := ptraceotlp.NewExportRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		received.Add(int64(req.Traces().SpanCount()))
+		writer.WriteHeader(http.StatusOK)
+	})})
+	go func() {
+		_ = srv.ServeListener(ln)
+	}()
+	defer func() { assert.NoError(t, errors.Join(srv.Close(), ln.Close())) }()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = "https://" + ln.Addr().String()
+	cfg.ClientConfig.TLSSetting = configtls.ClientConfig{
+		Config:     configtls.Config{CAFile: filepath.Join("testdata", "ca.crt")},
+		ServerName: "localhost",
+	}
+	cfg.ClientConfig.Compression = ""
+	cfg.QueueConfig.Enabled = false
+	cfg.HTTP3 = http3Cfg
+	exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.EqualValues(t, 2, received.Load())
+	// Shutting down closes the QUIC connections.
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
 func TestUserAgent(t *testing.T) {
 	set := exportertest.NewNopSettings()
 	set.BuildInfo.Description = "Collector"
@@ -458,7 +550,7 @@ func TestPartialSuccessInvalidBody(t *testing.T) {
 	}
 	for _, tt := range invalidBodyCases {
 		t.Run("Invalid response body_"+tt.telemetryType, func(t *testing.T) {
-			err := tt.handler([]byte{1}, "application/x-protobuf")
+			err := tt.handler(context.Background(), []byte{1}, "application/x-protobuf")
 			assert.ErrorContains(t, err, "error parsing protobuf response:")
 		})
 	}
@@ -482,7 +574,7 @@ func TestPartialSuccessUnsupportedContentType(t *testing.T) {
 	for _, telemetryType := range []string{"logs", "metrics", "traces", "profiles"} {
 		for _, tt := range unsupportedContentTypeCases {
 			t.Run("Unsupported content type "+tt.contentType+" "+telemetryType, func(t *testing.T) {
-				var handler func(b []byte, contentType string) error
+				var handler partialSuccessHandler
 				switch telemetryType {
 				case "logs":
 					handler = exp.logsPartialSuccessHandler
@@ -500,7 +592,7 @@ func TestPartialSuccessUnsupportedContentType(t *testing.T) {
 				exportResponse.PartialSuccess().SetRejectedSpans(42)
 				b, err := exportResponse.MarshalProto()
 				require.NoError(t, err)
-				err = handler(b, tt.contentType)
+				err = handler(context.Background(), b, tt.contentType)
 				assert.NoError(t, err)
 			})
 		}
@@ -615,7 +707,7 @@ func TestPartialResponse_missingHeaderButHasBody(t *testing.T) {
 						"Content-Type": {ct.contentType},
 					},
 				}
-				err = handlePartialSuccessResponse(resp, tt.handler)
+				err = handlePartialSuccessResponse(context.Background(), resp, tt.handler)
 				assert.NoError(t, err)
 			})
 		}
@@ -668,7 +760,7 @@ func TestPartialResponse_missingHeaderAndBody(t *testing.T) {
 						"Content-Type": {ct.contentType},
 					},
 				}
-				err = handlePartialSuccessResponse(resp, tt.handler)
+				err = handlePartialSuccessResponse(context.Background(), resp, tt.handler)
 				assert.NoError(t, err)
 			})
 		}
@@ -686,7 +778,7 @@ func TestPartialResponse_nonErrUnexpectedEOFError(t *testing.T) {
 		ContentLength: -1,
 		Body:          io.NopCloser(badReader{}),
 	}
-	err = handlePartialSuccessResponse(resp, exp.tracesPartialSuccessHandler)
+	err = handlePartialSuccessResponse(context.Background(), resp, exp.tracesPartialSuccessHandler)
 	assert.Error(t, err)
 }
 
@@ -756,7 +848,7 @@ func TestPartialSuccess_shortContentLengthHeader(t *testing.T) {
 					},
 				}
 				// For short content-length, a real error happens.
-				err = handlePartialSuccessResponse(resp, tt.handler)
+				err = handlePartialSuccessResponse(context.Background(), resp, tt.handler)
 				assert.Error(t, err)
 			})
 		}
@@ -841,7 +933,7 @@ func TestPartialSuccess_longContentLengthHeader(t *testing.T) {
 				}
 				// No real error happens for long content length, so the partial
 				// success is handled as success with a warning.
-				err = handlePartialSuccessResponse(resp, handler)
+				err = handlePartialSuccessResponse(context.Background(), resp, handler)
 				require.NoError(t, err)
 				assert.Len(t, observed.FilterLevelExact(zap.WarnLevel).All(), 1)
 				assert.Contains(t, observed.FilterLevelExact(zap.WarnLevel).All()[0].Message, "Partial success")
@@ -863,7 +955,7 @@ func TestPartialSuccessInvalidResponseBody(t *testing.T) {
 			"Content-Type": {protobufContentType},
 		},
 	}
-	err = handlePartialSuccessResponse(resp, exp.tracesPartialSuccessHandler)
+	err = handlePartialSuccessResponse(context.Background(), resp, exp.tracesPartialSuccessHandler)
 	assert.Error(t, err)
 }
 
@@ -5,6 +5,7 @@ package auth // import "go.opentelemetry.io/collector/extension/auth"
 
 import (
 	"context"
+	"net/http"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -29,6 +30,19 @@ type Server interface {
 	Authenticate(ctx context.Context, sources map[string][]string) (context.Context, error)
 }
 
+// HTTPServer is a Server which authenticates HTTP requests from their method, URL and body in addition
+// to their headers, e.g. to verify request signatures. HTTP servers call AuthenticateHTTP instead of
+// Authenticate when the authenticator implements it.
+type HTTPServer interface {
+	Server
+
+	// AuthenticateHTTP checks whether the given request holds valid auth data, as Authenticate does
+	// with its headers. The request must not be modified, except for its body which can be replaced,
+	// e.g. once it was read to verify its digest. The body is limited to the maximum request body
+	// size of the server.
+	AuthenticateHTTP(ctx context.Context, r *http.Request) (context.Context, error)
+}
+
 type defaultServer struct {
 	ServerAuthenticateFunc
 	component.StartFunc
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration for the health extension.
+type Config struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// LivenessPath is the path of the liveness endpoint. It fails when a component reported
+	// a fatal error, or when a recoverable error exceeded RecoverableErrors.LivenessThreshold.
+	LivenessPath string `mapstructure:"liveness_path"`
+
+	// ReadinessPath is the path of the readiness endpoint. It fails until the pipelines are
+	// ready to receive data, and when any component is unhealthy.
+	ReadinessPath string `mapstructure:"readiness_path"`
+
+	// StatusPath is the path of the endpoint reporting the health of every pipeline and component.
+	StatusPath string `mapstructure:"status_path"`
+
+	// IncludePermanentErrors makes components that reported a permanent error unhealthy.
+	IncludePermanentErrors bool `mapstructure:"include_permanent_errors"`
+
+	// RecoverableErrors configures when components reporting recoverable errors are unhealthy.
+	RecoverableErrors RecoverableErrorsConfig `mapstructure:"recoverable_errors"`
+}
+
+// RecoverableErrorsConfig configures how long components may report recoverable errors.
+type RecoverableErrorsConfig struct {
+	// ReadinessThreshold is how long a component can report a recoverable error before it is
+	// considered unhealthy, failing readiness. Zero makes it unhealthy immediately.
+	ReadinessThreshold time.Duration `mapstructure:"readiness_threshold"`
+
+	// LivenessThreshold is how long a component can report a recoverable error before failing
+	// liveness, so that the collector is restarted. Zero disables this check.
+	LivenessThreshold time.Duration `mapstructure:"liveness_threshold"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.ServerConfig.Endpoint == "" {
+		errs = append(errs, errors.New("\"endpoint\" is required when using the \"health\" extension"))
+	}
+	names := map[string]string{}
+	for _, p := range []struct{ name, path string }{
+		{"liveness_path", cfg.LivenessPath},
+		{"readiness_path", cfg.ReadinessPath},
+		{"status_path", cfg.StatusPath},
+	} {
+		if !strings.HasPrefix(p.path, "/") {
+			errs = append(errs, fmt.Errorf("%q must start with \"/\"", p.name))
+			continue
+		}
+		if other, ok := names[p.path]; ok {
+			errs = append(errs, fmt.Errorf("%q and %q must be different", other, p.name))
+		}
+		names[p.path] = p.name
+	}
+	if cfg.RecoverableErrors.ReadinessThreshold < 0 {
+		errs = append(errs, errors.New("\"recoverable_errors::readiness_threshold\" must not be negative"))
+	}
+	if cfg.RecoverableErrors.LivenessThreshold < 0 {
+		errs = append(errs, errors.New("\"recoverable_errors::liveness_threshold\" must not be negative"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			ServerConfig: confighttp.ServerConfig{
+				Endpoint: "localhost:13134",
+			},
+			LivenessPath:           "/livez",
+			ReadinessPath:          "/readyz",
+			StatusPath:             "/statusz",
+			IncludePermanentErrors: false,
+			RecoverableErrors: RecoverableErrorsConfig{
+				ReadinessThreshold: time.Minute,
+				LivenessThreshold:  10 * time.Minute,
+			},
+		}, cfg)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr string
+	}{
+		{
+			name:    "no endpoint",
+			mutate:  func(cfg *Config) { cfg.Endpoint = "" },
+			wantErr: `"endpoint" is required when using the "health" extension`,
+		},
+		{
+			name:    "relative path",
+			mutate:  func(cfg *Config) { cfg.StatusPath = "status" },
+			wantErr: `"status_path" must start with "/"`,
+		},
+		{
+			name:    "duplicate path",
+			mutate:  func(cfg *Config) { cfg.ReadinessPath = cfg.LivenessPath },
+			wantErr: `"liveness_path" and "readiness_path" must be different`,
+		},
+		{
+			name: "negative thresholds",
+			mutate: func(cfg *Config) {
+				cfg.RecoverableErrors.ReadinessThreshold = -time.Second
+				cfg.RecoverableErrors.LivenessThreshold = -time.Second
+			},
+			wantErr: "\"recoverable_errors::readiness_threshold\" must not be negative\n\"recoverable_errors::liveness_threshold\" must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+)
+
+// pipelineParam is the URL param of the status endpoint selecting a single pipeline.
+const pipelineParam = "pipeline"
+
+var (
+	_ extensioncapabilities.PipelineWatcher = (*healthExtension)(nil)
+	_ componentstatus.Watcher               = (*healthExtension)(nil)
+)
+
+type healthExtension struct {
+	config     *Config
+	telemetry  component.TelemetrySettings
+	aggregator *aggregator
+	server     *http.Server
+	stopCh     chan struct{}
+}
+
+func newHealthExtension(config *Config, telemetry component.TelemetrySettings) *healthExtension {
+	return &healthExtension{
+		config:     config,
+		telemetry:  telemetry,
+		aggregator: newAggregator(config),
+	}
+}
+
+func (he *healthExtension) Start(ctx context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(he.config.LivenessPath, he.handleLiveness)
+	mux.HandleFunc(he.config.ReadinessPath, he.handleReadiness)
+	mux.HandleFunc(he.config.StatusPath, he.handleStatus)
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := he.config.ToListener(ctx)
+	if err != nil {
+		return err
+	}
+
+	he.telemetry.Logger.Info("Starting health extension", zap.String("endpoint", ln.Addr().String()))
+	he.server, err = he.config.ToServer(ctx, host, he.telemetry, mux)
+	if err != nil {
+		return errors.Join(err, ln.Close())
+	}
+	he.stopCh = make(chan struct{})
+	go func() {
+		defer close(he.stopCh)
+
+		if errHTTP := he.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+
+	return nil
+}
+
+func (he *healthExtension) Shutdown(context.Context) error {
+	if he.server == nil {
+		return nil
+	}
+	err := he.server.Close()
+	if he.stopCh != nil {
+		<-he.stopCh
+	}
+	return err
+}
+
+// Ready implements extensioncapabilities.PipelineWatcher.
+func (he *healthExtension) Ready() error {
+	he.aggregator.setPipelinesReady(true)
+	return nil
+}
+
+// NotReady implements extensioncapabilities.PipelineWatcher.
+func (he *healthExtension) NotReady() error {
+	he.aggregator.setPipelinesReady(false)
+	return nil
+}
+
+// ComponentStatusChanged implements componentstatus.Watcher.
+func (he *healthExtension) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	he.aggregator.componentStatusChanged(source, event)
+}
+
+func (he *healthExtension) handleLiveness(w http.ResponseWriter, _ *http.Request) {
+	writeProbe(w, he.aggregator.health().Live)
+}
+
+func (he *healthExtension) handleReadiness(w http.ResponseWriter, _ *http.Request) {
+	writeProbe(w, he.aggregator.health().Ready)
+}
+
+func writeProbe(w http.ResponseWriter, ok bool) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if !ok {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("unavailable\n"))
+		return
+	}
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// handleStatus reports the health of every pipeline and component, or of a single pipeline
+// if the pipeline URL param is set. It responds with 503 if the reported health is not ready.
+func (he *healthExtension) handleStatus(w http.ResponseWriter, r *http.Request) {
+	health := he.aggregator.health()
+	var body any = health
+	ok := health.Ready
+	if name := r.URL.Query().Get(pipelineParam); name != "" {
+		pipelineHealth, found := health.Pipelines[name]
+		if !found {
+			http.Error(w, "unknown pipeline "+name, http.StatusNotFound)
+			return
+		}
+		body, ok = pipelineHealth, pipelineHealth.Healthy
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !ok {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		he.telemetry.Logger.Warn("Failed to write health status", zap.Error(err))
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func newTestExtension(t *testing.T) *healthExtension {
+	cfg := createDefaultConfig().(*Config)
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	cfg.Endpoint = ln.Addr().String()
+	require.NoError(t, ln.Close())
+	return newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+}
+
+func get(t *testing.T, url string) (int, string) {
+	resp, err := http.Get(url) //nolint:gosec
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestHealthExtensionProbes(t *testing.T) {
+	he := newTestExtension(t)
+	require.NoError(t, he.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, he.Shutdown(context.Background())) })
+	base := "http://" + he.config.Endpoint
+
+	he.ComponentStatusChanged(receiverID, componentstatus.NewEvent(componentstatus.StatusStarting))
+	code, _ := get(t, base+"/health/live")
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = get(t, base+"/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	he.ComponentStatusChanged(receiverID, componentstatus.NewEvent(componentstatus.StatusOK))
+	require.NoError(t, he.Ready())
+	code, body := get(t, base+"/health/ready")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok\n", body)
+
+	he.ComponentStatusChanged(receiverID, componentstatus.NewFatalErrorEvent(errors.New("port in use")))
+	code, body = get(t, base+"/health/live")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "unavailable\n", body)
+
+	require.NoError(t, he.NotReady())
+	code, _ = get(t, base+"/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+func TestHealthExtensionStatus(t *testing.T) {
+	he := newHealthExtension(createDefaultConfig().(*Config), componenttest.NewNopTelemetrySettings())
+	he.aggregator = newTestAggregator(he.config)
+	he.ComponentStatusChanged(exporterID, componentstatus.NewPermanentErrorEvent(errors.New("invalid endpoint")))
+
+	rec := httptest.NewRecorder()
+	he.handleStatus(rec, httptest.NewRequest(http.MethodGet, "/health/status", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var health Health
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &health))
+	assert.True(t, health.Live)
+	assert.False(t, health.Ready)
+	assert.Equal(t, "StatusPermanentError", health.Status)
+	assert.Equal(t, "invalid endpoint", health.Pipelines["metrics"].Components["exporter:debug"].Error)
+
+	rec = httptest.NewRecorder()
+	he.handleStatus(rec, httptest.NewRequest(http.MethodGet, "/health/status?pipeline=traces", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var group GroupHealth
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &group))
+	assert.True(t, group.Healthy)
+	assert.Equal(t, "StatusOK", group.Status)
+	assert.Len(t, group.Components, 2)
+
+	rec = httptest.NewRecorder()
+	he.handleStatus(rec, httptest.NewRequest(http.MethodGet, "/health/status?pipeline=metrics", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	rec = httptest.NewRecorder()
+	he.handleStatus(rec, httptest.NewRequest(http.MethodGet, "/health/status?pipeline=logs", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHealthExtensionStartError(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ln.Close()) })
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ln.Addr().String()
+	he := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.Error(t, he.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, he.Shutdown(context.Background()))
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// extensionsGroup is the name under which the health of extensions is reported,
+// since they do not belong to any pipeline.
+const extensionsGroup = "extensions"
+
+// componentState is the last status reported by a component instance.
+type componentState struct {
+	name      string
+	pipelines []string
+	status    componentstatus.Status
+	err       error
+	// since is the time the component entered its current status.
+	since time.Time
+}
+
+// ComponentHealth is the health of a component instance.
+type ComponentHealth struct {
+	Healthy bool      `json:"healthy"`
+	Status  string    `json:"status"`
+	Error   string    `json:"error,omitempty"`
+	Since   time.Time `json:"since"`
+}
+
+// GroupHealth is the aggregated health of the components of a pipeline, or of the extensions.
+type GroupHealth struct {
+	Healthy    bool                       `json:"healthy"`
+	Status     string                     `json:"status"`
+	Components map[string]ComponentHealth `json:"components"`
+}
+
+// Health is the health of the collector, reported by the status endpoint.
+type Health struct {
+	Live       bool                   `json:"live"`
+	Ready      bool                   `json:"ready"`
+	Status     string                 `json:"status"`
+	Pipelines  map[string]GroupHealth `json:"pipelines"`
+	Extensions *GroupHealth           `json:"extensions,omitempty"`
+}
+
+// aggregator keeps the last status reported by each component instance, and evaluates
+// the health of the collector according to the configured thresholds.
+type aggregator struct {
+	config *Config
+	now    func() time.Time
+
+	mu             sync.Mutex
+	pipelinesReady bool
+	components     map[string]*componentState
+}
+
+func newAggregator(config *Config) *aggregator {
+	return &aggregator{
+		config:     config,
+		now:        time.Now,
+		components: make(map[string]*componentState),
+	}
+}
+
+func (a *aggregator) componentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	var pipelines []string
+	source.AllPipelineIDs(func(id pipeline.ID) bool {
+		pipelines = append(pipelines, id.String())
+		return true
+	})
+	sort.Strings(pipelines)
+	name := strings.ToLower(source.Kind().String()) + ":" + source.ComponentID().String()
+	// Connectors are instantiated once per pair of pipeline signals, so the pipelines
+	// are part of the key identifying an instance.
+	key := name + "|" + strings.Join(pipelines, ",")
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	state, ok := a.components[key]
+	if !ok {
+		state = &componentState{name: name, pipelines: pipelines}
+		a.components[key] = state
+	}
+	if !ok || state.status != event.Status() {
+		state.since = event.Timestamp()
+	}
+	state.status = event.Status()
+	state.err = event.Err()
+}
+
+func (a *aggregator) setPipelinesReady(ready bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pipelinesReady = ready
+}
+
+// healthy returns whether a component in the given state is healthy for readiness.
+func (a *aggregator) healthy(state *componentState, now time.Time) bool {
+	switch state.status {
+	case componentstatus.StatusFatalError:
+		return false
+	case componentstatus.StatusPermanentError:
+		return !a.config.IncludePermanentErrors
+	case componentstatus.StatusRecoverableError:
+		return now.Sub(state.since) < a.config.RecoverableErrors.ReadinessThreshold
+	}
+	return true
+}
+
+// live returns whether a component in the given state allows the collector to keep running.
+func (a *aggregator) live(state *componentState, now time.Time) bool {
+	switch state.status {
+	case componentstatus.StatusFatalError:
+		return false
+	case componentstatus.StatusRecoverableError:
+		threshold := a.config.RecoverableErrors.LivenessThreshold
+		return threshold == 0 || now.Sub(state.since) < threshold
+	}
+	return true
+}
+
+// health evaluates the health of the collector.
+func (a *aggregator) health() Health {
+	now := a.now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	h := Health{
+		Live:      true,
+		Ready:     a.pipelinesReady,
+		Status:    componentstatus.StatusNone.String(),
+		Pipelines: make(map[string]GroupHealth),
+	}
+	overall := componentstatus.StatusNone
+	groups := make(map[string]*GroupHealth)
+	groupStatus := make(map[string]componentstatus.Status)
+	for _, state := range a.components {
+		healthy := a.healthy(state, now)
+		h.Live = h.Live && a.live(state, now)
+		h.Ready = h.Ready && healthy
+		overall = mostSevere(overall, state.status)
+
+		ch := ComponentHealth{Healthy: healthy, Status: state.status.String(), Since: state.since}
+		if state.err != nil {
+			ch.Error = state.err.Error()
+		}
+		names := state.pipelines
+		if len(names) == 0 {
+			names = []string{extensionsGroup}
+		}
+		for _, name := range names {
+			g, ok := groups[name]
+			if !ok {
+				g = &GroupHealth{Healthy: true, Components: make(map[string]ComponentHealth)}
+				groups[name] = g
+			}
+			g.Healthy = g.Healthy && healthy
+			// Instances of a connector share their name, report the unhealthy one if any.
+			if _, ok := g.Components[state.name]; !ok || !healthy {
+				g.Components[state.name] = ch
+			}
+			groupStatus[name] = mostSevere(groupStatus[name], state.status)
+		}
+	}
+	h.Status = overall.String()
+	for name, g := range groups {
+		g.Status = groupStatus[name].String()
+		if name == extensionsGroup {
+			h.Extensions = g
+			continue
+		}
+		h.Pipelines[name] = *g
+	}
+	return h
+}
+
+// severity orders statuses from the least to the most relevant for reporting the status
+// of a group of components.
+var severity = map[componentstatus.Status]int{
+	componentstatus.StatusNone:             0,
+	componentstatus.StatusOK:               1,
+	componentstatus.StatusStopped:          2,
+	componentstatus.StatusStopping:         3,
+	componentstatus.StatusStarting:         4,
+	componentstatus.StatusRecoverableError: 5,
+	componentstatus.StatusPermanentError:   6,
+	componentstatus.StatusFatalError:       7,
+}
+
+func mostSevere(a, b componentstatus.Status) componentstatus.Status {
+	if severity[b] > severity[a] {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+var (
+	tracesID  = pipeline.NewID(pipeline.SignalTraces)
+	metricsID = pipeline.NewID(pipeline.SignalMetrics)
+
+	receiverID  = componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindReceiver, tracesID, metricsID)
+	processorID = componentstatus.NewInstanceID(component.MustNewID("batch"), component.KindProcessor, tracesID)
+	exporterID  = componentstatus.NewInstanceID(component.MustNewID("debug"), component.KindExporter, metricsID)
+	extensionID = componentstatus.NewInstanceID(component.MustNewID("health"), component.KindExtension)
+)
+
+// newTestAggregator returns an aggregator with all components started and the pipelines ready.
+func newTestAggregator(cfg *Config) *aggregator {
+	a := newAggregator(cfg)
+	for _, id := range []*componentstatus.InstanceID{receiverID, processorID, exporterID, extensionID} {
+		a.componentStatusChanged(id, componentstatus.NewEvent(componentstatus.StatusStarting))
+		a.componentStatusChanged(id, componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+	a.setPipelinesReady(true)
+	return a
+}
+
+func TestAggregatorHealthy(t *testing.T) {
+	a := newTestAggregator(createDefaultConfig().(*Config))
+	h := a.health()
+	assert.True(t, h.Live)
+	assert.True(t, h.Ready)
+	assert.Equal(t, "StatusOK", h.Status)
+	assert.Len(t, h.Pipelines, 2)
+	assert.Len(t, h.Pipelines["traces"].Components, 2)
+	assert.Contains(t, h.Pipelines["traces"].Components, "receiver:otlp")
+	assert.Contains(t, h.Pipelines["traces"].Components, "processor:batch")
+	assert.Len(t, h.Pipelines["metrics"].Components, 2)
+	assert.Contains(t, h.Pipelines["metrics"].Components, "exporter:debug")
+	assert.Equal(t, &GroupHealth{
+		Healthy: true,
+		Status:  "StatusOK",
+		Components: map[string]ComponentHealth{
+			"extension:health": h.Extensions.Components["extension:health"],
+		},
+	}, h.Extensions)
+
+	a.setPipelinesReady(false)
+	h = a.health()
+	assert.True(t, h.Live)
+	assert.False(t, h.Ready)
+}
+
+func TestAggregatorRecoverableError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RecoverableErrors.ReadinessThreshold = time.Minute
+	cfg.RecoverableErrors.LivenessThreshold = 10 * time.Minute
+	a := newTestAggregator(cfg)
+	start := time.Now()
+	a.componentStatusChanged(exporterID, componentstatus.NewRecoverableErrorEvent(errors.New("connection refused")))
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		live    bool
+		ready   bool
+	}{
+		{name: "within readiness threshold", elapsed: 0, live: true, ready: true},
+		{name: "after readiness threshold", elapsed: 2 * time.Minute, live: true, ready: false},
+		{name: "after liveness threshold", elapsed: 11 * time.Minute, live: false, ready: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.now = func() time.Time { return start.Add(tt.elapsed) }
+			h := a.health()
+			assert.Equal(t, tt.live, h.Live)
+			assert.Equal(t, tt.ready, h.Ready)
+			assert.Equal(t, "StatusRecoverableError", h.Status)
+			assert.Equal(t, tt.ready, h.Pipelines["metrics"].Healthy)
+			assert.True(t, h.Pipelines["traces"].Healthy)
+			assert.Equal(t, "StatusRecoverableError", h.Pipelines["metrics"].Status)
+			assert.Equal(t, "StatusOK", h.Pipelines["traces"].Status)
+			assert.Equal(t, "connection refused", h.Pipelines["metrics"].Components["exporter:debug"].Error)
+		})
+	}
+
+	// Repeated recoverable errors do not reset the time the component entered the status.
+	a.componentStatusChanged(exporterID, componentstatus.NewRecoverableErrorEvent(errors.New("connection refused")))
+	a.now = func() time.Time { return start.Add(2 * time.Minute) }
+	assert.False(t, a.health().Ready)
+
+	// Recovering makes the collector ready again.
+	a.componentStatusChanged(exporterID, componentstatus.NewEvent(componentstatus.StatusOK))
+	assert.True(t, a.health().Ready)
+}
+
+func TestAggregatorLivenessThresholdDisabled(t *testing.T) {
+	a := newTestAggregator(createDefaultConfig().(*Config))
+	start := time.Now()
+	a.componentStatusChanged(exporterID, componentstatus.NewRecoverableErrorEvent(errors.New("connection refused")))
+	a.now = func() time.Time { return start.Add(24 * time.Hour) }
+	h := a.health()
+	assert.True(t, h.Live)
+	assert.False(t, h.Ready)
+}
+
+func TestAggregatorPermanentError(t *testing.T) {
+	for _, include := range []bool{true, false} {
+		cfg := createDefaultConfig().(*Config)
+		cfg.IncludePermanentErrors = include
+		a := newTestAggregator(cfg)
+		a.componentStatusChanged(processorID, componentstatus.NewPermanentErrorEvent(errors.New("invalid")))
+		h := a.health()
+		assert.True(t, h.Live)
+		assert.Equal(t, !include, h.Ready)
+		assert.Equal(t, !include, h.Pipelines["traces"].Healthy)
+		assert.Equal(t, "StatusPermanentError", h.Status)
+	}
+}
+
+func TestAggregatorFatalError(t *testing.T) {
+	a := newTestAggregator(createDefaultConfig().(*Config))
+	a.componentStatusChanged(extensionID, componentstatus.NewFatalErrorEvent(errors.New("port in use")))
+	h := a.health()
+	assert.False(t, h.Live)
+	assert.False(t, h.Ready)
+	assert.Equal(t, "StatusFatalError", h.Status)
+	assert.False(t, h.Extensions.Healthy)
+	assert.True(t, h.Pipelines["traces"].Healthy)
+}
+
+func TestAggregatorConnectorInstances(t *testing.T) {
+	a := newTestAggregator(createDefaultConfig().(*Config))
+	connID := component.MustNewID("forward")
+	logsID := pipeline.NewID(pipeline.SignalLogs)
+	a.componentStatusChanged(componentstatus.NewInstanceID(connID, component.KindConnector, tracesID, metricsID), componentstatus.NewEvent(componentstatus.StatusOK))
+	a.componentStatusChanged(componentstatus.NewInstanceID(connID, component.KindConnector, tracesID, logsID), componentstatus.NewPermanentErrorEvent(errors.New("invalid")))
+	a.componentStatusChanged(componentstatus.NewInstanceID(connID, component.KindConnector, tracesID, metricsID), componentstatus.NewEvent(componentstatus.StatusOK))
+
+	h := a.health()
+	assert.False(t, h.Pipelines["traces"].Healthy)
+	assert.Equal(t, "StatusPermanentError", h.Pipelines["traces"].Components["connector:forward"].Status)
+	assert.True(t, h.Pipelines["metrics"].Healthy)
+	assert.False(t, h.Pipelines["logs"].Healthy)
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hmacauthextension // import "go.opentelemetry.io/collector/extension/hmacauthextension"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9._~-]+$`)
+
+// Config has the configuration for the HMAC request signing authenticator.
+type Config struct {
+	// KeyID identifies the secret the requests are signed with. It is sent with the signature
+	// of the requests, so that servers select the secret to verify the signature with.
+	KeyID string `mapstructure:"key_id"`
+
+	// Secret is the secret the requests of the key KeyID are signed and verified with.
+	Secret configopaque.String `mapstructure:"secret"`
+
+	// AdditionalKeys are the secrets, by key ID, the signatures of the requests are verified
+	// with in addition to Secret, e.g. while the secret of the clients is rotated.
+	AdditionalKeys map[string]configopaque.String `mapstructure:"additional_keys"`
+
+	// MaxSkew is the maximum difference between the timestamp of a signed request and the
+	// clock of the server. The servers reject the requests outside of this window, and the
+	// requests replayed within it. Default is 5m.
+	MaxSkew time.Duration `mapstructure:"max_skew"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs []error
+	if !keyIDPattern.MatchString(cfg.KeyID) {
+		errs = append(errs, fmt.Errorf("\"key_id\" must be a non-empty string of letters, digits, '.', '_', '~' or '-': %q", cfg.KeyID))
+	}
+	if cfg.Secret == "" {
+		errs = append(errs, errors.New("\"secret\" is required when using the \"hmacauth\" extension"))
+	}
+	for keyID, secret := range cfg.AdditionalKeys {
+		if !keyIDPattern.MatchString(keyID) {
+			errs = append(errs, fmt.Errorf("\"additional_keys\" key IDs must be strings of letters, digits, '.', '_', '~' or '-': %q", keyID))
+		}
+		if keyID == cfg.KeyID {
+			errs = append(errs, fmt.Errorf("\"additional_keys\" must not hold \"key_id\": %q", keyID))
+		}
+		if secret == "" {
+			errs = append(errs, fmt.Errorf("the secret of %q in \"additional_keys\" must not be empty", keyID))
+		}
+	}
+	if cfg.MaxSkew <= 0 {
+		errs = append(errs, errors.New("\"max_skew\" must be positive"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hmacauthextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+	// The key has no default.
+	assert.Error(t, cfg.(*Config).Validate())
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			KeyID:  "collector-1",
+			Secret: "secret-1",
+			AdditionalKeys: map[string]configopaque.String{
+				"collector-0": "secret-0",
+			},
+			MaxSkew: time.Minute,
+		}, cfg)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr string
+	}{
+		{
+			name:    "no key_id",
+			mutate:  func(cfg *Config) { cfg.KeyID = "" },
+			wantErr: `"key_id" must be a non-empty string of letters, digits, '.', '_', '~' or '-': ""`,
+		},
+		{
+			name:    "invalid key_id",
+			mutate:  func(cfg *Config) { cfg.KeyID = "key,id" },
+			wantErr: `"key_id" must be a non-empty string of letters, digits, '.', '_', '~' or '-': "key,id"`,
+		},
+		{
+			name:    "no secret",
+			mutate:  func(cfg *Config) { cfg.Secret = "" },
+			wantErr: `"secret" is required when using the "hmacauth" extension`,
+		},
+		{
+			name:    "invalid additional key_id",
+			mutate:  func(cfg *Config) { cfg.AdditionalKeys = map[string]configopaque.String{"key id": "secret"} },
+			wantErr: `"additional_keys" key IDs must be strings of letters, digits, '.', '_', '~' or '-': "key id"`,
+		},
+		{
+			name:    "additional key_id",
+			mutate:  func(cfg *Config) { cfg.AdditionalKeys = map[string]configopaque.String{"collector": "secret"} },
+			wantErr: `"additional_keys" must not hold "key_id": "collector"`,
+		},
+		{
+			name:    "empty additional secret",
+			mutate:  func(cfg *Config) { cfg.AdditionalKeys = map[string]configopaque.String{"previous": ""} },
+			wantErr: `the secret of "previous" in "additional_keys" must not be empty`,
+		},
+		{
+			name:    "zero max_skew",
+			mutate:  func(cfg *Config) { cfg.MaxSkew = 0 },
+			wantErr: `"max_skew" must be positive`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.KeyID = "collector"
+			cfg.Secret = "secret"
+			tt.mutate(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hmacauthextension // import "go.opentelemetry.io/collector/extension/hmacauthextension"
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/auth"
+)
+
+var (
+	errHTTPOnly        = errors.New("the hmacauth extension only authenticates HTTP requests")
+	errUnknownKey      = errors.New("unknown signature key")
+	errTimestampSkew   = errors.New("the signature timestamp is outside of the allowed skew")
+	errReplayedRequest = errors.New("replayed request")
+)
+
+var (
+	_ auth.Client     = (*hmacAuth)(nil)
+	_ auth.HTTPServer = (*hmacAuth)(nil)
+)
+
+// hmacAuth signs the HTTP requests sent by clients, and verifies the signature of the
+// HTTP requests received by servers.
+type hmacAuth struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	keyID   string
+	secret  []byte
+	keys    map[string][]byte
+	maxSkew time.Duration
+	nonces  *nonceCache
+	now     func() time.Time
+}
+
+func newHMACAuth(cfg *Config) *hmacAuth {
+	keys := map[string][]byte{cfg.KeyID: []byte(cfg.Secret)}
+	for keyID, secret := range cfg.AdditionalKeys {
+		keys[keyID] = []byte(secret)
+	}
+	return &hmacAuth{
+		keyID:   cfg.KeyID,
+		secret:  []byte(cfg.Secret),
+		keys:    keys,
+		maxSkew: cfg.MaxSkew,
+		nonces:  newNonceCache(),
+		now:     time.Now,
+	}
+}
+
+// RoundTripper returns a RoundTripper signing the requests.
+func (a *hmacAuth) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &signingRoundTripper{base: base, auth: a}, nil
+}
+
+// PerRPCCredentials isn't supported: the signature covers the body of the requests, which
+// isn't available to gRPC credentials.
+func (a *hmacAuth) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return nil, errHTTPOnly
+}
+
+// Authenticate isn't supported: the signature covers the method, the path and the body of
+// the requests, which aren't part of their headers. HTTP servers call AuthenticateHTTP.
+func (a *hmacAuth) Authenticate(ctx context.Context, _ map[string][]string) (context.Context, error) {
+	return ctx, errHTTPOnly
+}
+
+// AuthenticateHTTP verifies the signature of the request, rejecting it if its timestamp
+// is outside of the allowed skew, if it was already received or if its body doesn't match
+// its signed digest. The body is buffered to be verified before the request is handled. The
+// key ID of the signature is added to the client info as the "key_id" auth attribute.
+func (a *hmacAuth) AuthenticateHTTP(ctx context.Context, r *http.Request) (context.Context, error) {
+	sig, err := parseSignature(r.Header.Get("Authorization"))
+	if err != nil {
+		return ctx, err
+	}
+	secret, ok := a.keys[sig.keyID]
+	if !ok {
+		return ctx, fmt.Errorf("%w: %q", errUnknownKey, sig.keyID)
+	}
+	now := a.now()
+	signedAt := time.Unix(sig.timestamp, 0)
+	if skew := now.Sub(signedAt); skew > a.maxSkew || skew < -a.maxSkew {
+		return ctx, errTimestampSkew
+	}
+	bodyDigest := r.Header.Get(contentSHA256Header)
+	digest, err := hex.DecodeString(bodyDigest)
+	if err != nil || len(digest) != sha256.Size {
+		return ctx, fmt.Errorf("invalid %s header", contentSHA256Header)
+	}
+	if !hmac.Equal(sign(secret, sig, r, bodyDigest), sig.value) {
+		return ctx, errInvalidSignature
+	}
+	// The body is read, up to the maximum request body size of the server, and verified before
+	// the request is handled, since the receivers may forward its data before reading all of it.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return ctx, err
+	}
+	if sum := sha256.Sum256(body); !hmac.Equal(sum[:], digest) {
+		return ctx, errBodyDigest
+	}
+	// Only the nonces of valid signatures are recorded, until their timestamp is outside of the skew.
+	if !a.nonces.add(sig.keyID+":"+sig.nonce, signedAt.Add(a.maxSkew), now) {
+		return ctx, errReplayedRequest
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	info := client.FromContext(ctx)
+	info.Auth = authData{keyID: sig.keyID}
+	return client.NewContext(ctx, info), nil
+}
+
+// signingRoundTripper signs the requests before sending them with its base RoundTripper.
+type signingRoundTripper struct {
+	base http.RoundTripper
+	auth *hmacAuth
+}
+
+func (rt *signingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if closeErr := req.Body.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	digest := sha256.Sum256(body)
+	bodyDigest := hex.EncodeToString(digest[:])
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sig := signature{
+		keyID:     rt.auth.keyID,
+		timestamp: rt.auth.now().Unix(),
+		nonce:     hex.EncodeToString(nonce),
+	}
+	sig.value = sign(rt.auth.secret, sig, req, bodyDigest)
+
+	req = req.Clone(req.Context())
+	if body != nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+	req.Header.Set(contentSHA256Header, bodyDigest)
+	req.Header.Set("Authorization", sig.header())
+	return rt.base.RoundTrip(req)
+}
+
+// authData exposes the key ID of the signature of the requests.
+type authData struct {
+	keyID string
+}
+
+func (a authData) GetAttribute(name string) any {
+	if name == "key_id" {
+		return a.keyID
+	}
+	return nil
+}
+
+func (authData) GetAttributeNames() []string {
+	return []string{"key_id"}
+}
@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hmacauthextension
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+var (
+	clientID = component.MustNewIDWithName("hmacauth", "client")
+	serverID = component.MustNewIDWithName("hmacauth", "server")
+)
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func newTestAuth(keyID, secret string) *hmacAuth {
+	cfg := createDefaultConfig().(*Config)
+	cfg.KeyID = keyID
+	cfg.Secret = configopaque.String(secret)
+	return newHMACAuth(cfg)
+}
+
+// newSignedServer starts an HTTP server verifying the signatures with the server authenticator,
+// and returns a client signing its requests with the client authenticator.
+func newSignedServer(t *testing.T, clientAuth, serverAuth *hmacAuth, handler http.HandlerFunc) (*http.Client, string) {
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{clientID: clientAuth, serverID: serverAuth},
+	}
+	hss := confighttp.ServerConfig{
+		Auth: &confighttp.AuthConfig{Authentication: configauth.Authentication{AuthenticatorID: serverID}},
+	}
+	srv, err := hss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings(), handler)
+	require.NoError(t, err)
+	ts := httptest.NewServer(srv.Handler)
+	t.Cleanup(ts.Close)
+
+	hcs := confighttp.ClientConfig{
+		Endpoint: ts.URL,
+		Auth:     &configauth.Authentication{AuthenticatorID: clientID},
+	}
+	c, err := hcs.ToClient(context.Background(), host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(c.CloseIdleConnections)
+	return c, ts.URL
+}
+
+func TestSignedRequests(t *testing.T) {
+	var keyID any
+	c, url := newSignedServer(t, newTestAuth("collector", "secret"), newTestAuth("collector", "secret"),
+		func(w http.ResponseWriter, r *http.Request) {
+			keyID = client.FromContext(r.Context()).Auth.GetAttribute("key_id")
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write(body)
+		})
+
+	resp, err := c.Post(url+"/v1/traces?tenant=a", "text/plain", strings.NewReader("body"))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "body", string(body))
+	assert.Equal(t, "collector", keyID)
+
+	resp, err = c.Get(url + "/")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestRejectedRequests(t *testing.T) {
+	serverAuth := newTestAuth("collector", "secret")
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}
+	_, url := newSignedServer(t, newTestAuth("collector", "secret"), serverAuth, handler)
+
+	// signedRequest returns a request signed at the given time, and lets it be tampered with after signing.
+	signedRequest := func(clientAuth *hmacAuth, at time.Time, tamper func(*http.Request)) *http.Request {
+		clientAuth.now = func() time.Time { return at }
+		req, err := http.NewRequest(http.MethodPost, url+"/v1/traces", strings.NewReader("body"))
+		require.NoError(t, err)
+		var signed *http.Request
+		rt, err := clientAuth.RoundTripper(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			signed = r
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}))
+		require.NoError(t, err)
+		_, err = rt.RoundTrip(req)
+		require.NoError(t, err)
+		if tamper != nil {
+			tamper(signed)
+		}
+		return signed
+	}
+	send := func(req *http.Request) int {
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+
+	now := time.Now()
+	tests := []struct {
+		name       string
+		req        *http.Request
+		wantStatus int
+	}{
+		{
+			name:       "unsigned",
+			req:        signedRequest(newTestAuth("collector", "secret"), now, func(r *http.Request) { r.Header.Del("Authorization") }),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong secret",
+			req:        signedRequest(newTestAuth("collector", "other"), now, nil),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unknown key",
+			req:        signedRequest(newTestAuth("other", "secret"), now, nil),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "expired",
+			req:        signedRequest(newTestAuth("collector", "secret"), now.Add(-time.Hour), nil),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "from the future",
+			req:        signedRequest(newTestAuth("collector", "secret"), now.Add(time.Hour), nil),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "tampered path",
+			req:        signedRequest(newTestAuth("collector", "secret"), now, func(r *http.Request) { r.URL.Path = "/v1/logs" }),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "tampered digest",
+			req: signedRequest(newTestAuth("collector", "secret"), now, func(r *http.Request) {
+				r.Header.Set(contentSHA256Header, strings.Repeat("0", 64))
+			}),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "tampered body",
+			req: signedRequest(newTestAuth("collector", "secret"), now, func(r *http.Request) {
+				r.Body = io.NopCloser(strings.NewReader("tampered"))
+				r.ContentLength = int64(len("tampered"))
+			}),
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantStatus, send(tt.req))
+		})
+	}
+
+	t.Run("replayed", func(t *testing.T) {
+		req := signedRequest(newTestAuth("collector", "secret"), now, nil)
+		replayed := req.Clone(context.Background())
+		replayed.Body, _ = req.GetBody()
+		assert.Equal(t, http.StatusOK, send(req))
+		assert.Equal(t, http.StatusUnauthorized, send(replayed))
+	})
+}
+
+func TestTamperedBodyNotHandled(t *testing.T) {
+	// The handler forwards each line of the body before reading all of it, like the NDJSON
+	// handler of the OTLP receiver.
+	var sink []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			sink = append(sink, scanner.Text())
+		}
+		if scanner.Err() != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}
+	_, url := newSignedServer(t, newTestAuth("collector", "secret"), newTestAuth("collector", "secret"), handler)
+
+	req, err := http.NewRequest(http.MethodPost, url+"/v1/logs", strings.NewReader("{\"resourceLogs\":[]}\n"))
+	require.NoError(t, err)
+	rt, err := newTestAuth("collector", "secret").RoundTripper(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		r.Body = io.NopCloser(strings.NewReader("{\"resourceLogs\":[]}\n{\"tampered\":true}\n"))
+		r.ContentLength = -1
+		return http.DefaultTransport.RoundTrip(r)
+	}))
+	require.NoError(t, err)
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Empty(t, sink)
+}
+
+func TestAdditionalKeys(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.KeyID = "collector-1"
+	cfg.Secret = "secret-1"
+	cfg.AdditionalKeys = map[string]configopaque.String{"collector-0": "secret-0"}
+	c, url := newSignedServer(t, newTestAuth("collector-0", "secret-0"), newHMACAuth(cfg),
+		func(http.ResponseWriter, *http.Request) {})
+
+	resp, err := c.Get(url)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestGRPCNotSupported(t *testing.T) {
+	a := newTestAuth("collector", "secret")
+	_, err := a.PerRPCCredentials()
+	require.ErrorIs(t, err, errHTTPOnly)
+	_, err = a.Authenticate(context.Background(), map[string][]string{"authorization": {"OTEL-HMAC-SHA256"}})
+	require.ErrorIs(t, err, errHTTPOnly)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hmacauthextension // import "go.opentelemetry.io/collector/extension/hmacauthextension"
+
+//go:generate mdatagen metadata.yaml
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/hmacauthextension/internal/metadata"
+)
+
+const defaultMaxSkew = 5 * time.Minute
+
+// NewFactory creates a factory for the HMAC request signing authenticator.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		MaxSkew: defaultMaxSkew,
+	}
+}
+
+// create creates the extension based on this config.
+func create(_ context.Context, _ extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newHMACAuth(cfg.(*Config)), nil
+}
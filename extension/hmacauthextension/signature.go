@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hmacauthextension // import "go.opentelemetry.io/collector/extension/hmacauthextension"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// scheme is the scheme of the Authorization header holding the signature of a request.
+	scheme = "OTEL-HMAC-SHA256"
+	// contentSHA256Header holds the hex encoded SHA-256 digest of the body of a signed request.
+	contentSHA256Header = "X-Otel-Content-Sha256"
+	// maxNonceLength is the maximum length of the nonces, which servers keep until they expire.
+	maxNonceLength = 64
+)
+
+var (
+	errMissingSignature = errors.New("missing " + scheme + " signature")
+	errInvalidSignature = errors.New("invalid signature")
+	errBodyDigest       = errors.New("the body doesn't match its signed digest")
+)
+
+// signature holds the parameters of the Authorization header of a signed request:
+//
+//	Authorization: OTEL-HMAC-SHA256 KeyId=<key ID>,Timestamp=<unix seconds>,Nonce=<hex>,Signature=<hex>
+type signature struct {
+	keyID     string
+	timestamp int64
+	nonce     string
+	value     []byte
+}
+
+// stringToSign returns the string the signature of a request is computed over: the scheme,
+// the timestamp and the nonce of the signature, the method, the path and the query of the
+// request, and the hex encoded SHA-256 digest of its body, separated by newlines.
+func stringToSign(sig signature, r *http.Request, bodyDigest string) string {
+	path := r.URL.EscapedPath()
+	if path == "" {
+		// Clients send the requests without path to "/".
+		path = "/"
+	}
+	return strings.Join([]string{
+		scheme,
+		strconv.FormatInt(sig.timestamp, 10),
+		sig.nonce,
+		r.Method,
+		path,
+		r.URL.RawQuery,
+		bodyDigest,
+	}, "\n")
+}
+
+// sign computes the HMAC-SHA256 of the string to sign of the request with the secret.
+func sign(secret []byte, sig signature, r *http.Request, bodyDigest string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(stringToSign(sig, r, bodyDigest)))
+	return mac.Sum(nil)
+}
+
+func (sig signature) header() string {
+	return fmt.Sprintf("%s KeyId=%s,Timestamp=%d,Nonce=%s,Signature=%s",
+		scheme, sig.keyID, sig.timestamp, sig.nonce, hex.EncodeToString(sig.value))
+}
+
+func parseSignature(header string) (signature, error) {
+	params, ok := strings.CutPrefix(header, scheme+" ")
+	if !ok {
+		return signature{}, errMissingSignature
+	}
+	var sig signature
+	var err error
+	for _, param := range strings.Split(params, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		switch name {
+		case "KeyId":
+			sig.keyID = value
+		case "Timestamp":
+			if sig.timestamp, err = strconv.ParseInt(value, 10, 64); err != nil {
+				return signature{}, fmt.Errorf("invalid signature timestamp %q", value)
+			}
+		case "Nonce":
+			sig.nonce = value
+		case "Signature":
+			if sig.value, err = hex.DecodeString(value); err != nil {
+				return signature{}, errInvalidSignature
+			}
+		}
+	}
+	if sig.keyID == "" || sig.timestamp == 0 || sig.nonce == "" || len(sig.nonce) > maxNonceLength || len(sig.value) == 0 {
+		return signature{}, errInvalidSignature
+	}
+	return sig, nil
+}
+
+// nonceCache records the nonces of the signatures until their timestamp is outside of the
+// skew window, so that the requests replayed within the window are rejected.
+type nonceCache struct {
+	mu        sync.Mutex
+	expiries  map[string]time.Time
+	lastPurge time.Time
+}
+
+func newNonceCache() *nonceCache {
+	return &nonceCache{expiries: map[string]time.Time{}}
+}
+
+// add records the nonce until its expiry, and returns false if it is already recorded.
+func (c *nonceCache) add(nonce string, expiry, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.Sub(c.lastPurge) >= time.Second {
+		for n, e := range c.expiries {
+			if !now.Before(e) {
+				delete(c.expiries, n)
+			}
+		}
+		c.lastPurge = now
+	}
+	if e, ok := c.expiries[nonce]; ok && now.Before(e) {
+		return false
+	}
+	c.expiries[nonce] = expiry
+	return true
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hmacauthextension
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSignature(t *testing.T) {
+	sig := signature{keyID: "collector", timestamp: 1700000000, nonce: "0123456789abcdef", value: []byte{0xca, 0xfe}}
+	parsed, err := parseSignature(sig.header())
+	require.NoError(t, err)
+	assert.Equal(t, sig, parsed)
+
+	for _, header := range []string{
+		"",
+		"Bearer token",
+		"OTEL-HMAC-SHA256 KeyId=collector,Timestamp=1700000000,Nonce=0123",
+		"OTEL-HMAC-SHA256 KeyId=collector,Timestamp=1700000000,Nonce=0123,Signature=zz",
+		"OTEL-HMAC-SHA256 KeyId=collector,Timestamp=now,Nonce=0123,Signature=cafe",
+		"OTEL-HMAC-SHA256 KeyId=collector,Timestamp=1700000000,Nonce=" + strings.Repeat("0", maxNonceLength+1) + ",Signature=cafe",
+	} {
+		_, err = parseSignature(header)
+		assert.Error(t, err, header)
+	}
+}
+
+func TestNonceCache(t *testing.T) {
+	c := newNonceCache()
+	now := time.Unix(1700000000, 0)
+	assert.True(t, c.add("a", now.Add(time.Minute), now))
+	assert.False(t, c.add("a", now.Add(time.Minute), now.Add(30*time.Second)))
+	assert.True(t, c.add("b", now.Add(2*time.Minute), now.Add(30*time.Second)))
+
+	// Expired nonces are purged.
+	assert.True(t, c.add("c", now.Add(3*time.Minute), now.Add(90*time.Second)))
+	assert.Len(t, c.expiries, 2)
+	assert.False(t, c.add("b", now.Add(2*time.Minute), now.Add(90*time.Second)))
+}
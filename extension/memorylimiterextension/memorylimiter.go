@@ -14,6 +14,8 @@ import (
 
 type memoryLimiterExtension struct {
 	memLimiter *memorylimiter.MemoryLimiter
+	// budget is the name of the budget of the callers not naming one.
+	budget string
 }
 
 // newMemoryLimiter returns a new memorylimiter extension.
@@ -23,7 +25,7 @@ func newMemoryLimiter(cfg *Config, logger *zap.Logger) (*memoryLimiterExtension,
 		return nil, err
 	}
 
-	return &memoryLimiterExtension{memLimiter: ml}, nil
+	return &memoryLimiterExtension{memLimiter: ml, budget: cfg.Budget}, nil
 }
 
 func (ml *memoryLimiterExtension) Start(ctx context.Context, host component.Host) error {
@@ -34,7 +36,18 @@ func (ml *memoryLimiterExtension) Shutdown(ctx context.Context) error {
 	return ml.memLimiter.Shutdown(ctx)
 }
 
-// MustRefuse returns if the caller should deny because memory has reached it's configured limits
+// MustRefuse returns if the caller should deny because memory has reached it's configured limits,
+// or the share of the limits of the configured budget.
 func (ml *memoryLimiterExtension) MustRefuse() bool {
-	return ml.memLimiter.MustRefuse()
+	return ml.memLimiter.MustRefuseBudget(ml.budget)
+}
+
+// MustRefuseBudget returns whether the data attributed to the named budget must be refused,
+// because memory usage is above either the budget's share of the limits or the limits.
+// The data of callers not naming a budget is attributed to the configured budget.
+func (ml *memoryLimiterExtension) MustRefuseBudget(name string) bool {
+	if name == "" {
+		name = ml.budget
+	}
+	return ml.memLimiter.MustRefuseBudget(name)
 }
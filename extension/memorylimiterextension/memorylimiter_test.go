@@ -105,3 +105,38 @@ func (h *mockHost) GetExtensions() map[component.ID]component.Component {
 func totalMemory() (uint64, error) {
 	return uint64(2048), nil
 }
+
+func TestBudgetMemoryPressureResponse(t *testing.T) {
+	memorylimiter.GetMemoryFn = totalMemory
+	// Above the budget of 500, below the soft limit of 1000.
+	memorylimiter.ReadMemStatsFn = func(ms *runtime.MemStats) {
+		ms.Alloc = 800
+	}
+	t.Cleanup(func() {
+		memorylimiter.GetMemoryFn = iruntime.TotalMemory
+		memorylimiter.ReadMemStatsFn = runtime.ReadMemStats
+	})
+
+	ml, err := newMemoryLimiter(&Config{
+		CheckInterval:         time.Second,
+		MemoryLimitPercentage: 50,
+		MemorySpikePercentage: 1,
+		Budgets: map[string]memorylimiter.BudgetConfig{
+			"receiver/otlp": {LimitPercentage: 50},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ml.Start(context.Background(), &mockHost{}))
+	ml.memLimiter.CheckMemLimits()
+	assert.False(t, ml.MustRefuse())
+	assert.True(t, ml.MustRefuseBudget("receiver/otlp"))
+	assert.False(t, ml.MustRefuseBudget("receiver/other"))
+	assert.False(t, ml.MustRefuseBudget(""))
+	require.NoError(t, ml.Shutdown(context.Background()))
+
+	// The callers not naming a budget consult the configured one.
+	ml.budget = "receiver/otlp"
+	assert.True(t, ml.MustRefuse())
+	assert.True(t, ml.MustRefuseBudget(""))
+	assert.False(t, ml.MustRefuseBudget("receiver/other"))
+}
@@ -13,6 +13,10 @@ import (
 // Config has the configuration for the extension enabling the zPages extension.
 type Config struct {
 	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// AllowFeatureGateToggle allows the feature gates that support it to be enabled and disabled
+	// at runtime from the featurez page. It requires "auth" to be configured.
+	AllowFeatureGateToggle bool `mapstructure:"allow_feature_gate_toggle"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -22,5 +26,8 @@ func (cfg *Config) Validate() error {
 	if cfg.ServerConfig.Endpoint == "" {
 		return errors.New("\"endpoint\" is required when using the \"zpages\" extension")
 	}
+	if cfg.AllowFeatureGateToggle && cfg.ServerConfig.Auth == nil {
+		return errors.New("\"auth\" is required when \"allow_feature_gate_toggle\" is enabled")
+	}
 	return nil
 }
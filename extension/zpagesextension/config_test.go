@@ -24,6 +24,10 @@ func TestUnmarshalDefaultConfig(t *testing.T) {
 
 func TestInvalidConfig(t *testing.T) {
 	assert.Error(t, (&Config{}).Validate())
+	assert.EqualError(t, (&Config{
+		ServerConfig:           confighttp.ServerConfig{Endpoint: "localhost:55679"},
+		AllowFeatureGateToggle: true,
+	}).Validate(), `"auth" is required when "allow_feature_gate_toggle" is enabled`)
 }
 
 func TestUnmarshalConfig(t *testing.T) {
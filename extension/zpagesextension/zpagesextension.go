@@ -66,6 +66,18 @@ func (zpe *zpagesExtension) Start(ctx context.Context, host component.Host) erro
 		zpe.telemetry.Logger.Warn("Host's zPages not available")
 	}
 
+	if zpe.config.AllowFeatureGateToggle {
+		hostFeatureGates, ok := host.(interface {
+			EnableFeatureGateToggle()
+		})
+		if ok {
+			hostFeatureGates.EnableFeatureGateToggle()
+			zpe.telemetry.Logger.Info("Enabled setting feature gates at runtime from the Host's zPages")
+		} else {
+			zpe.telemetry.Logger.Warn("Setting feature gates at runtime is not available")
+		}
+	}
+
 	// Start the listener here so we can have earlier failure if port is
 	// already in use.
 	ln, err := zpe.config.ToListener(ctx)
@@ -23,6 +23,7 @@ import (
 
 type zpagesHost struct {
 	component.Host
+	featureGateToggle bool
 }
 
 func newZPagesHost() *zpagesHost {
@@ -31,6 +32,10 @@ func newZPagesHost() *zpagesHost {
 
 func (*zpagesHost) RegisterZPages(*http.ServeMux, string) {}
 
+func (h *zpagesHost) EnableFeatureGateToggle() {
+	h.featureGateToggle = true
+}
+
 var (
 	_ registerableTracerProvider = (*registerableProvider)(nil)
 	_ registerableTracerProvider = sdktrace.NewTracerProvider()
@@ -51,7 +56,7 @@ func newZpagesTelemetrySettings() component.TelemetrySettings {
 
 func TestZPagesExtensionUsage(t *testing.T) {
 	cfg := &Config{
-		confighttp.ServerConfig{
+		ServerConfig: confighttp.ServerConfig{
 			Endpoint: testutil.GetAvailableLocalAddress(t),
 		},
 	}
@@ -76,9 +81,25 @@ func TestZPagesExtensionUsage(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func TestZPagesExtensionFeatureGateToggle(t *testing.T) {
+	for _, allow := range []bool{false, true} {
+		cfg := &Config{
+			ServerConfig: confighttp.ServerConfig{
+				Endpoint: testutil.GetAvailableLocalAddress(t),
+			},
+			AllowFeatureGateToggle: allow,
+		}
+		zpagesExt := newServer(cfg, newZpagesTelemetrySettings())
+		host := newZPagesHost()
+		require.NoError(t, zpagesExt.Start(context.Background(), host))
+		require.NoError(t, zpagesExt.Shutdown(context.Background()))
+		require.Equal(t, allow, host.featureGateToggle)
+	}
+}
+
 func TestZPagesExtensionBadAuthExtension(t *testing.T) {
 	cfg := &Config{
-		confighttp.ServerConfig{
+		ServerConfig: confighttp.ServerConfig{
 			Endpoint: "localhost:0",
 			Auth: &confighttp.AuthConfig{
 				Authentication: configauth.Authentication{
@@ -98,7 +119,7 @@ func TestZPagesExtensionPortAlreadyInUse(t *testing.T) {
 	defer ln.Close()
 
 	cfg := &Config{
-		confighttp.ServerConfig{
+		ServerConfig: confighttp.ServerConfig{
 			Endpoint: endpoint,
 		},
 	}
@@ -110,7 +131,7 @@ func TestZPagesExtensionPortAlreadyInUse(t *testing.T) {
 
 func TestZPagesMultipleStarts(t *testing.T) {
 	cfg := &Config{
-		confighttp.ServerConfig{
+		ServerConfig: confighttp.ServerConfig{
 			Endpoint: testutil.GetAvailableLocalAddress(t),
 		},
 	}
@@ -127,7 +148,7 @@ func TestZPagesMultipleStarts(t *testing.T) {
 
 func TestZPagesMultipleShutdowns(t *testing.T) {
 	cfg := &Config{
-		confighttp.ServerConfig{
+		ServerConfig: confighttp.ServerConfig{
 			Endpoint: testutil.GetAvailableLocalAddress(t),
 		},
 	}
@@ -142,7 +163,7 @@ func TestZPagesMultipleShutdowns(t *testing.T) {
 
 func TestZPagesShutdownWithoutStart(t *testing.T) {
 	cfg := &Config{
-		confighttp.ServerConfig{
+		ServerConfig: confighttp.ServerConfig{
 			Endpoint: testutil.GetAvailableLocalAddress(t),
 		},
 	}
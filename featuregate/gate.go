@@ -20,6 +20,8 @@ type Gate struct {
 	toVersion    *version.Version
 	stage        Stage
 	enabled      *atomic.Bool
+	// runtimeToggle is true if the Gate can be enabled or disabled while the collector is running.
+	runtimeToggle bool
 }
 
 // ID returns the id of the Gate.
@@ -32,6 +34,12 @@ func (g *Gate) IsEnabled() bool {
 	return g.enabled.Load()
 }
 
+// RuntimeToggle returns true if the Gate can be enabled or disabled while the collector is running,
+// using Registry.SetAtRuntime.
+func (g *Gate) RuntimeToggle() bool {
+	return g.runtimeToggle
+}
+
 // Description returns the description for the Gate.
 func (g *Gate) Description() string {
 	return g.description
@@ -47,12 +55,20 @@ func (g *Gate) ReferenceURL() string {
 	return g.referenceURL
 }
 
-// FromVersion returns the version information when the Gate's was added.
+// FromVersion returns the version information when the Gate's was added,
+// or an empty string if it is not set.
 func (g *Gate) FromVersion() string {
+	if g.fromVersion == nil {
+		return ""
+	}
 	return fmt.Sprintf("v%s", g.fromVersion)
 }
 
-// ToVersion returns the version information when Gate's in StageStable.
+// ToVersion returns the version information when Gate's in StageStable,
+// or an empty string if it is not set.
 func (g *Gate) ToVersion() string {
+	if g.toVersion == nil {
+		return ""
+	}
 	return fmt.Sprintf("v%s", g.toVersion)
 }
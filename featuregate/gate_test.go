@@ -38,3 +38,9 @@ func TestGate(t *testing.T) {
 	assert.Equal(t, "v0.61.0", g.FromVersion())
 	assert.Equal(t, "v0.64.0", g.ToVersion())
 }
+
+func TestGateWithoutVersions(t *testing.T) {
+	g := &Gate{id: "test", stage: StageAlpha, enabled: &atomic.Bool{}}
+	assert.Empty(t, g.FromVersion())
+	assert.Empty(t, g.ToVersion())
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"slices"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -105,6 +106,16 @@ func WithRegisterToVersion(toVersion string) RegisterOption {
 	})
 }
 
+// WithRegisterRuntimeToggle declares that the Gate can be enabled or disabled while the collector
+// is running. Only set it if the code checking the Gate supports the value changing at any time,
+// for example because it calls IsEnabled for every operation instead of once at startup.
+func WithRegisterRuntimeToggle() RegisterOption {
+	return registerOptionFunc(func(g *Gate) error {
+		g.runtimeToggle = true
+		return nil
+	})
+}
+
 // MustRegister like Register but panics if an invalid ID or gate options are provided.
 func (r *Registry) MustRegister(id string, stage Stage, opts ...RegisterOption) *Gate {
 	g, err := r.Register(id, stage, opts...)
@@ -195,6 +206,44 @@ func (r *Registry) Set(id string, enabled bool) error {
 	return nil
 }
 
+// SetAtRuntime sets the enabled value for a Gate identified by the given id while the collector is running.
+// Only Alpha and Beta gates registered using WithRegisterRuntimeToggle can be set.
+// It returns whether the value changed.
+func (r *Registry) SetAtRuntime(id string, enabled bool) (bool, error) {
+	v, ok := r.gates.Load(id)
+	if !ok {
+		return false, fmt.Errorf("no such feature gate %q", id)
+	}
+	g := v.(*Gate)
+	if g.stage != StageAlpha && g.stage != StageBeta {
+		return false, fmt.Errorf("feature gate %q is %v, can not be set at runtime", id, g.stage)
+	}
+	if !g.runtimeToggle {
+		return false, fmt.Errorf("feature gate %q does not support being set at runtime", id)
+	}
+	return g.enabled.Swap(enabled) != enabled, nil
+}
+
+// CheckLifecycle returns an error if any Gate outlived its ToVersion, meaning that it should have
+// been removed before currentVersion, the version of the collector being built. Pre-release and build
+// metadata of currentVersion are ignored, so that the development versions of a release do not
+// fail for the gates that can still be used in it. The gates listed in knownOverdue, whose removal
+// is tracked separately, do not fail the check.
+func (r *Registry) CheckLifecycle(currentVersion string, knownOverdue ...string) error {
+	current, err := version.NewVersion(currentVersion)
+	if err != nil {
+		return fmt.Errorf("invalid current version %q: %w", currentVersion, err)
+	}
+	current = current.Core()
+	var errs error
+	r.VisitAll(func(g *Gate) {
+		if g.toVersion != nil && g.toVersion.LessThan(current) && !slices.Contains(knownOverdue, g.id) {
+			errs = errors.Join(errs, fmt.Errorf("feature gate %q outlived its removal version %v, current version is v%v", g.id, g.ToVersion(), current))
+		}
+	})
+	return errs
+}
+
 // VisitAll visits all the gates in lexicographical order, calling fn for each.
 func (r *Registry) VisitAll(fn func(*Gate)) {
 	var gates []*Gate
@@ -207,3 +207,44 @@ func TestRegisterGateLifecycle(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistrySetAtRuntime(t *testing.T) {
+	r := NewRegistry()
+	alpha := r.MustRegister("alpha", StageAlpha, WithRegisterRuntimeToggle())
+	r.MustRegister("beta", StageBeta)
+	r.MustRegister("stable", StageStable, WithRegisterRuntimeToggle(), WithRegisterToVersion("v0.100.0"))
+	assert.True(t, alpha.RuntimeToggle())
+
+	changed, err := r.SetAtRuntime("alpha", true)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, alpha.IsEnabled())
+
+	changed, err = r.SetAtRuntime("alpha", true)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	_, err = r.SetAtRuntime("beta", false)
+	require.EqualError(t, err, `feature gate "beta" does not support being set at runtime`)
+	_, err = r.SetAtRuntime("stable", false)
+	require.EqualError(t, err, `feature gate "stable" is Stable, can not be set at runtime`)
+	_, err = r.SetAtRuntime("unknown", false)
+	require.EqualError(t, err, `no such feature gate "unknown"`)
+}
+
+func TestRegistryCheckLifecycle(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister("alpha", StageAlpha, WithRegisterFromVersion("v0.60.0"))
+	r.MustRegister("beta", StageBeta, WithRegisterToVersion("v0.70.0"))
+	r.MustRegister("stable", StageStable, WithRegisterToVersion("v0.65.0"))
+	r.MustRegister("deprecated", StageDeprecated, WithRegisterToVersion("v0.64.0"))
+
+	require.NoError(t, r.CheckLifecycle("v0.64.0"))
+	require.NoError(t, r.CheckLifecycle("0.64.0-dev"))
+	require.EqualError(t, r.CheckLifecycle("v0.66.0-rc.1"),
+		`feature gate "deprecated" outlived its removal version v0.64.0, current version is v0.66.0`+"\n"+
+			`feature gate "stable" outlived its removal version v0.65.0, current version is v0.66.0`)
+	require.EqualError(t, r.CheckLifecycle("v0.66.0", "stable"),
+		`feature gate "deprecated" outlived its removal version v0.64.0, current version is v0.66.0`)
+	require.ErrorContains(t, r.CheckLifecycle("latest"), `invalid current version "latest"`)
+}
@@ -28,11 +28,13 @@
 package cgroups // import "go.opentelemetry.io/collector/internal/memorylimiter/cgroups"
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -53,6 +55,9 @@ const (
 	// _cgroupv2MemoryMax is the file name for the CGroup-V2 Memory max
 	// parameter.
 	_cgroupv2MemoryMax = "memory.max"
+	// _cgroupv2MemoryPressure is the file name for the CGroup-V2 Memory
+	// pressure stall information.
+	_cgroupv2MemoryPressure = "memory.pressure"
 	// _cgroupFSType is the Linux CGroup-V2 file system type used in
 	// `/proc/$PID/mountinfo`.
 	_cgroupv2FSType = "cgroup2"
@@ -180,3 +185,23 @@ func memoryQuotaV2(cgroupv2MountPoint, cgroupv2MemoryMax string) (int64, bool, e
 	}
 	return -1, false, io.ErrUnexpectedEOF
 }
+
+// MemoryPressureTriggerV2 registers a cgroupv2 `memory.pressure` trigger, notifying
+// when some tasks of the cgroup are stalled on memory for more than stall within
+// window (see https://docs.kernel.org/accounting/psi.html). Notifications are
+// received as POLLPRI events on the returned file, for as long as it is open.
+func MemoryPressureTriggerV2(stall, window time.Duration) (*os.File, error) {
+	return memoryPressureTriggerV2(_cgroupv2MountPoint, _cgroupv2MemoryPressure, stall, window)
+}
+
+func memoryPressureTriggerV2(cgroupv2MountPoint, cgroupv2MemoryPressure string, stall, window time.Duration) (*os.File, error) {
+	trigger, err := os.OpenFile(filepath.Clean(filepath.Join(cgroupv2MountPoint, cgroupv2MemoryPressure)), os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = fmt.Fprintf(trigger, "some %d %d", stall.Microseconds(), window.Microseconds()); err != nil {
+		_ = trigger.Close()
+		return nil, err
+	}
+	return trigger, nil
+}
@@ -28,8 +28,10 @@
 package cgroups
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -226,3 +228,18 @@ func TestCGroupsMemoryQuotaV2(t *testing.T) {
 		}
 	}
 }
+
+func TestCGroupsMemoryPressureTriggerV2(t *testing.T) {
+	_, err := memoryPressureTriggerV2("nonexistent", "nonexistent", time.Second, 2*time.Second)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	cgroupPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupPath, "memory.pressure"), nil, 0o600))
+	trigger, err := memoryPressureTriggerV2(cgroupPath, "memory.pressure", 100*time.Millisecond, 2*time.Second)
+	require.NoError(t, err)
+	require.NoError(t, trigger.Close())
+
+	written, err := os.ReadFile(filepath.Join(cgroupPath, "memory.pressure"))
+	require.NoError(t, err)
+	assert.Equal(t, "some 100000 2000000", string(written))
+}
@@ -5,6 +5,7 @@ package memorylimiter // import "go.opentelemetry.io/collector/internal/memoryli
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -17,15 +18,32 @@ var (
 	errSpikeLimitPercentageOutOfRange = errors.New("'spike_limit_percentage' must be smaller than 'limit_percentage'")
 	errLimitPercentageOutOfRange      = errors.New(
 		"'limit_percentage' and 'spike_limit_percentage' must be greater than zero and less than or equal to hundred")
+	errBudgetPercentageOutOfRange = errors.New("'limit_percentage' must be greater than zero and less than or equal to hundred")
+	errUnknownBudget              = errors.New("'budget' must be the name of one of the 'budgets'")
+	errModeInvalid                = fmt.Errorf("'mode' must be either %q or %q", ModePoll, ModePressure)
+)
+
+const (
+	// ModePoll checks the memory usage every check interval, and forces a GC when it
+	// is above the limits.
+	ModePoll = "poll"
+	// ModePressure sets the memory limit of the Go runtime to the configured limit, and
+	// checks the memory usage when the cgroup v2 of the process is under memory pressure.
+	ModePressure = "pressure"
 )
 
 // Config defines configuration for memory memoryLimiter processor.
 type Config struct {
 	// CheckInterval is the time between measurements of memory usage for the
 	// purposes of avoiding going over the limits. Defaults to zero, so no
-	// checks will be performed.
+	// checks will be performed. In ModePressure, the memory usage is also
+	// measured when the cgroup v2 of the process is under memory pressure.
 	CheckInterval time.Duration `mapstructure:"check_interval"`
 
+	// Mode is how memory usage is monitored, either ModePoll or ModePressure.
+	// Defaults to ModePoll.
+	Mode string `mapstructure:"mode"`
+
 	// MemoryLimitMiB is the maximum amount of memory, in MiB, targeted to be
 	// allocated by the process.
 	MemoryLimitMiB uint32 `mapstructure:"limit_mib"`
@@ -41,6 +59,24 @@ type Config struct {
 	// MemorySpikePercentage is the maximum, in percents against the total memory,
 	// spike expected between the measurements of memory usage.
 	MemorySpikePercentage uint32 `mapstructure:"spike_limit_percentage"`
+
+	// Budgets are shares of the memory limit, by name. The data attributed to a budget
+	// is refused once the memory usage is above its share of the soft limit, while the
+	// other data keeps being accepted until the soft limit is reached.
+	Budgets map[string]BudgetConfig `mapstructure:"budgets"`
+
+	// Budget is the name of the budget, among Budgets, the data is attributed to when
+	// the caller doesn't name one: the data going through the memory limiter processor,
+	// or the requests of the servers using the memory limiter extension without naming
+	// a budget. By default, the data is only refused above the soft limit.
+	Budget string `mapstructure:"budget"`
+}
+
+// BudgetConfig defines a share of the memory limit.
+type BudgetConfig struct {
+	// LimitPercentage is the percentage of the soft limit, the memory limit minus the
+	// spike limit, from which the data attributed to the budget is refused.
+	LimitPercentage uint32 `mapstructure:"limit_percentage"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -50,6 +86,9 @@ func (cfg *Config) Validate() error {
 	if cfg.CheckInterval <= 0 {
 		return errCheckIntervalOutOfRange
 	}
+	if cfg.Mode != "" && cfg.Mode != ModePoll && cfg.Mode != ModePressure {
+		return errModeInvalid
+	}
 	if cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
 		return errLimitOutOfRange
 	}
@@ -62,5 +101,13 @@ func (cfg *Config) Validate() error {
 	if cfg.MemoryLimitPercentage > 0 && cfg.MemoryLimitPercentage <= cfg.MemorySpikePercentage {
 		return errSpikeLimitPercentageOutOfRange
 	}
+	for name, budget := range cfg.Budgets {
+		if budget.LimitPercentage == 0 || budget.LimitPercentage > 100 {
+			return fmt.Errorf("budget %q: %w", name, errBudgetPercentageOutOfRange)
+		}
+	}
+	if _, ok := cfg.Budgets[cfg.Budget]; cfg.Budget != "" && !ok {
+		return errUnknownBudget
+	}
 	return nil
 }
@@ -22,8 +22,12 @@ func TestUnmarshalConfig(t *testing.T) {
 	assert.Equal(t,
 		&Config{
 			CheckInterval:       5 * time.Second,
+			Mode:                ModePoll,
 			MemoryLimitMiB:      4000,
 			MemorySpikeLimitMiB: 500,
+			Budgets: map[string]BudgetConfig{
+				"logs": {LimitPercentage: 50},
+			},
 		}, cfg)
 }
 
@@ -84,6 +88,24 @@ func TestConfigValidate(t *testing.T) {
 			},
 			err: errSpikeLimitPercentageOutOfRange,
 		},
+		{
+			name: "pressure mode",
+			cfg: &Config{
+				CheckInterval:  1 * time.Second,
+				Mode:           ModePressure,
+				MemoryLimitMiB: 100,
+			},
+			err: nil,
+		},
+		{
+			name: "invalid mode",
+			cfg: &Config{
+				CheckInterval:  1 * time.Second,
+				Mode:           "push",
+				MemoryLimitMiB: 100,
+			},
+			err: errModeInvalid,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -93,6 +115,30 @@ func TestConfigValidate(t *testing.T) {
 	}
 }
 
+func TestConfigValidateBudgets(t *testing.T) {
+	cfg := &Config{
+		CheckInterval:  1 * time.Second,
+		MemoryLimitMiB: 100,
+		Budgets: map[string]BudgetConfig{
+			"logs": {LimitPercentage: 50},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	for _, percentage := range []uint32{0, 101} {
+		cfg.Budgets["traces"] = BudgetConfig{LimitPercentage: percentage}
+		err := cfg.Validate()
+		require.ErrorIs(t, err, errBudgetPercentageOutOfRange)
+		assert.ErrorContains(t, err, `budget "traces"`)
+	}
+
+	delete(cfg.Budgets, "traces")
+	cfg.Budget = "logs"
+	require.NoError(t, cfg.Validate())
+	cfg.Budget = "traces"
+	assert.Equal(t, errUnknownBudget, cfg.Validate())
+}
+
 func TestUnmarshalInvalidConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "negative_unsigned_limits_config.yaml"))
 	require.NoError(t, err)
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package iruntime // import "go.opentelemetry.io/collector/internal/memorylimiter/iruntime"
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"go.opentelemetry.io/collector/internal/memorylimiter/cgroups"
+)
+
+var errMemoryPressureTriggerInvalid = errors.New("memory pressure trigger is no longer valid")
+
+// MemoryPressure notifies of memory pressure on the cgroup of the process.
+type MemoryPressure struct {
+	trigger *os.File
+	// wake is an eventfd interrupting Wait.
+	wake int
+}
+
+// NewMemoryPressure returns a MemoryPressure notifying when some tasks of the cgroup
+// of the process are stalled on memory for more than stall within window.
+// This implementation is meant for linux and requires cgroups v2.
+func NewMemoryPressure(stall, window time.Duration) (*MemoryPressure, error) {
+	isV2, err := cgroups.IsCGroupV2()
+	if err != nil {
+		return nil, err
+	}
+	if !isV2 {
+		return nil, errors.New("memory pressure notifications require cgroups v2")
+	}
+
+	trigger, err := cgroups.MemoryPressureTriggerV2(stall, window)
+	if err != nil {
+		return nil, err
+	}
+	wake, err := unix.Eventfd(0, unix.EFD_CLOEXEC|unix.EFD_NONBLOCK)
+	if err != nil {
+		_ = trigger.Close()
+		return nil, err
+	}
+	return &MemoryPressure{trigger: trigger, wake: wake}, nil
+}
+
+// Wait waits for memory pressure for at most timeout, or without limit if timeout is negative.
+// It returns true if memory pressure was notified, and false on timeout or once Wake was called.
+func (p *MemoryPressure) Wait(timeout time.Duration) (bool, error) {
+	msec := -1
+	if timeout >= 0 {
+		msec = int(timeout.Milliseconds())
+	}
+	fds := []unix.PollFd{
+		// nolint:gosec
+		{Fd: int32(p.trigger.Fd()), Events: unix.POLLPRI},
+		{Fd: int32(p.wake), Events: unix.POLLIN},
+	}
+	for {
+		_, err := unix.Poll(fds, msec)
+		if errors.Is(err, unix.EINTR) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if fds[0].Revents&unix.POLLERR != 0 {
+			return false, errMemoryPressureTriggerInvalid
+		}
+		return fds[0].Revents&unix.POLLPRI != 0, nil
+	}
+}
+
+// Wake makes any current and later call to Wait return.
+func (p *MemoryPressure) Wake() {
+	var buf [8]byte
+	binary.NativeEndian.PutUint64(buf[:], 1)
+	_, _ = unix.Write(p.wake, buf[:])
+}
+
+// Close unregisters the memory pressure trigger.
+func (p *MemoryPressure) Close() error {
+	return errors.Join(p.trigger.Close(), unix.Close(p.wake))
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package iruntime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryPressure(t *testing.T) {
+	p, err := NewMemoryPressure(100*time.Millisecond, 2*time.Second)
+	if err != nil {
+		t.Skipf("memory pressure notifications are not available: %v", err)
+	}
+	t.Cleanup(func() { assert.NoError(t, p.Close()) })
+
+	_, err = p.Wait(10 * time.Millisecond)
+	require.NoError(t, err)
+
+	p.Wake()
+	pressured, err := p.Wait(-1)
+	require.NoError(t, err)
+	assert.False(t, pressured)
+}
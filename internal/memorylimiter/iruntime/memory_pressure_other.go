@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package iruntime // import "go.opentelemetry.io/collector/internal/memorylimiter/iruntime"
+
+import (
+	"errors"
+	"time"
+)
+
+// MemoryPressure notifies of memory pressure, which is not supported on non-linux platforms.
+type MemoryPressure struct{}
+
+// NewMemoryPressure returns an error for non-linux platforms.
+func NewMemoryPressure(time.Duration, time.Duration) (*MemoryPressure, error) {
+	return nil, errors.New("memory pressure notifications are only supported on linux")
+}
+
+// Wait returns immediately for non-linux platforms.
+func (*MemoryPressure) Wait(time.Duration) (bool, error) {
+	return false, nil
+}
+
+// Wake does nothing for non-linux platforms.
+func (*MemoryPressure) Wake() {}
+
+// Close does nothing for non-linux platforms.
+func (*MemoryPressure) Close() error {
+	return nil
+}
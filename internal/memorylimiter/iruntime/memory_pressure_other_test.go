@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package iruntime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryPressure(t *testing.T) {
+	_, err := NewMemoryPressure(100*time.Millisecond, 2*time.Second)
+	assert.Error(t, err)
+}
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"runtime/debug"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -15,6 +17,7 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/memorylimiter/iruntime"
 )
 
@@ -24,12 +27,18 @@ const (
 	// Minimum interval between forced GC when in soft limited mode. We don't want to
 	// do GCs too frequently since it is a CPU-heavy operation.
 	minGCIntervalWhenSoftLimited = 10 * time.Second
+
+	// Memory pressure is notified when some tasks are stalled on memory for more than
+	// pressureStallThreshold within pressureWindow. Unprivileged processes can only use
+	// windows which are multiples of 2s.
+	pressureStallThreshold = 100 * time.Millisecond
+	pressureWindow         = 2 * time.Second
 )
 
 var (
 	// ErrDataRefused will be returned to callers of ConsumeTraceData to indicate
 	// that data is being refused due to high memory usage.
-	ErrDataRefused = errors.New("data refused due to high memory usage")
+	ErrDataRefused = consumererror.ErrDataRefused
 
 	// ErrShutdownNotStarted indicates no memorylimiter has not start when shutdown
 	ErrShutdownNotStarted = errors.New("no existing monitoring routine is running")
@@ -37,8 +46,65 @@ var (
 	// GetMemoryFn and ReadMemStatsFn make it overridable by tests
 	GetMemoryFn    = iruntime.TotalMemory
 	ReadMemStatsFn = runtime.ReadMemStats
+
+	newMemoryPressureFn = func(stall, window time.Duration) (memoryPressure, error) {
+		return iruntime.NewMemoryPressure(stall, window)
+	}
+
+	// runtimeMemoryLimit is shared by the memory limiters in pressure mode, since the Go
+	// runtime memory limit is process-wide.
+	runtimeMemoryLimit = &memoryLimitSetter{limits: make(map[*MemoryLimiter]int64)}
 )
 
+// memoryLimitSetter sets the Go runtime memory limit to the lowest limit of the running
+// memory limiters in pressure mode, and restores the previous limit once none is running.
+type memoryLimitSetter struct {
+	mu     sync.Mutex
+	limits map[*MemoryLimiter]int64
+	prev   int64
+}
+
+func (s *memoryLimitSetter) add(ml *MemoryLimiter, limit int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.limits) == 0 {
+		s.prev = debug.SetMemoryLimit(-1)
+	}
+	s.limits[ml] = limit
+	s.apply()
+}
+
+func (s *memoryLimitSetter) remove(ml *MemoryLimiter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.limits[ml]; !ok {
+		return
+	}
+	delete(s.limits, ml)
+	if len(s.limits) == 0 {
+		debug.SetMemoryLimit(s.prev)
+		return
+	}
+	s.apply()
+}
+
+func (s *memoryLimitSetter) apply() {
+	limit := int64(-1)
+	for _, l := range s.limits {
+		if limit < 0 || l < limit {
+			limit = l
+		}
+	}
+	debug.SetMemoryLimit(limit)
+}
+
+// memoryPressure notifies of memory pressure, see iruntime.MemoryPressure.
+type memoryPressure interface {
+	Wait(timeout time.Duration) (bool, error)
+	Wake()
+	Close() error
+}
+
 // MemoryLimiter is used to prevent out of memory situations on the collector.
 type MemoryLimiter struct {
 	usageChecker memUsageChecker
@@ -48,8 +114,16 @@ type MemoryLimiter struct {
 	// mustRefuse is used to indicate when data should be refused.
 	mustRefuse *atomic.Bool
 
+	// budgets are the shares of the memory limit, sorted by name.
+	budgets []*budget
+
 	ticker *time.Ticker
 
+	// pressureMode is set to check memory usage upon memory pressure, besides every
+	// memCheckWait, relying on the Go runtime memory limit instead of forced GCs.
+	pressureMode bool
+	pressure     memoryPressure
+
 	lastGCDone time.Time
 
 	// The function to read the mem values is set as a reference to help with
@@ -75,7 +149,19 @@ func NewMemoryLimiter(cfg *Config, logger *zap.Logger) (*MemoryLimiter, error) {
 	logger.Info("Memory limiter configured",
 		zap.Uint64("limit_mib", usageChecker.memAllocLimit/mibBytes),
 		zap.Uint64("spike_limit_mib", usageChecker.memSpikeLimit/mibBytes),
-		zap.Duration("check_interval", cfg.CheckInterval))
+		zap.Duration("check_interval", cfg.CheckInterval),
+		zap.String("mode", cfg.Mode))
+
+	budgets := make([]*budget, 0, len(cfg.Budgets))
+	for name, bcfg := range cfg.Budgets {
+		budgets = append(budgets, &budget{name: name, limitPercentage: uint64(bcfg.LimitPercentage)})
+	}
+	sort.Slice(budgets, func(i, j int) bool { return budgets[i].name < budgets[j].name })
+	for _, b := range budgets {
+		logger.Info("Memory limiter budget configured",
+			zap.String("budget", b.name),
+			zap.Uint64("limit_mib", usageChecker.budgetLimit(b.limitPercentage)/mibBytes))
+	}
 
 	return &MemoryLimiter{
 		usageChecker:   *usageChecker,
@@ -84,37 +170,85 @@ func NewMemoryLimiter(cfg *Config, logger *zap.Logger) (*MemoryLimiter, error) {
 		readMemStatsFn: ReadMemStatsFn,
 		logger:         logger,
 		mustRefuse:     &atomic.Bool{},
+		budgets:        budgets,
+		pressureMode:   cfg.Mode == ModePressure,
 	}, nil
 }
 
-// startMonitoring starts a single ticker'd goroutine per instance
-// that will check memory usage every checkInterval period.
-func (ml *MemoryLimiter) startMonitoring() {
+// budget is a share of the memory limit.
+type budget struct {
+	name            string
+	limitPercentage uint64
+	mustRefuse      atomic.Bool
+}
+
+// startMonitoring starts a single goroutine per instance that will check
+// memory usage every checkInterval period, and upon memory pressure in pressure mode.
+func (ml *MemoryLimiter) startMonitoring() error {
 	ml.refCounterLock.Lock()
 	defer ml.refCounterLock.Unlock()
 
-	ml.refCounter++
-	if ml.refCounter == 1 {
+	if ml.refCounter == 0 {
+		if ml.pressureMode {
+			pressure, err := newMemoryPressureFn(pressureStallThreshold, pressureWindow)
+			if err != nil {
+				return fmt.Errorf("failed to watch memory pressure, use mode %q: %w", ModePoll, err)
+			}
+			ml.pressure = pressure
+			// nolint:gosec
+			runtimeMemoryLimit.add(ml, int64(ml.usageChecker.memAllocLimit))
+		}
 		ml.closed = make(chan struct{})
 		ml.waitGroup.Add(1)
 		go func() {
 			defer ml.waitGroup.Done()
-
-			for {
-				select {
-				case <-ml.ticker.C:
-				case <-ml.closed:
-					return
-				}
-				ml.CheckMemLimits()
+			if ml.pressureMode {
+				ml.monitorPressure()
+			} else {
+				ml.monitorTicker()
 			}
 		}()
 	}
+	ml.refCounter++
+	return nil
+}
+
+func (ml *MemoryLimiter) monitorTicker() {
+	for {
+		select {
+		case <-ml.ticker.C:
+		case <-ml.closed:
+			return
+		}
+		ml.CheckMemLimits()
+	}
+}
+
+// monitorPressure checks memory usage when memory pressure is notified, and every
+// checkInterval period since the end of memory pressure is not notified, and memory
+// usage may reach the limits without the tasks of the cgroup being stalled.
+func (ml *MemoryLimiter) monitorPressure() {
+	for {
+		pressured, err := ml.pressure.Wait(ml.memCheckWait)
+		select {
+		case <-ml.closed:
+			return
+		default:
+		}
+		if err != nil {
+			ml.logger.Error("Failed to wait for memory pressure. Checking memory usage every check interval.", zap.Error(err))
+			ml.monitorTicker()
+			return
+		}
+		if pressured {
+			ml.logger.Debug("Memory pressure notified.")
+		}
+		ml.CheckMemLimits()
+	}
 }
 
 func (ml *MemoryLimiter) Start(_ context.Context, _ component.Host) error {
-	ml.startMonitoring()
-	return nil
+	return ml.startMonitoring()
 }
 
 // Shutdown resets MemoryLimiter monitoring ticker and stop monitoring
@@ -122,15 +256,24 @@ func (ml *MemoryLimiter) Shutdown(context.Context) error {
 	ml.refCounterLock.Lock()
 	defer ml.refCounterLock.Unlock()
 
+	var err error
 	if ml.refCounter == 0 {
 		return ErrShutdownNotStarted
 	} else if ml.refCounter == 1 {
 		ml.ticker.Stop()
 		close(ml.closed)
+		if ml.pressure != nil {
+			ml.pressure.Wake()
+		}
 		ml.waitGroup.Wait()
+		if ml.pressure != nil {
+			runtimeMemoryLimit.remove(ml)
+			err = ml.pressure.Close()
+			ml.pressure = nil
+		}
 	}
 	ml.refCounter--
-	return nil
+	return err
 }
 
 // MustRefuse returns if the caller should deny because memory has reached it's configured limits
@@ -138,6 +281,21 @@ func (ml *MemoryLimiter) MustRefuse() bool {
 	return ml.mustRefuse.Load()
 }
 
+// MustRefuseBudget returns if the caller should deny data attributed to the named budget,
+// because memory has reached either the budget's share of the limits or the limits.
+// Data attributed to an unknown budget is only denied when memory reached the limits.
+func (ml *MemoryLimiter) MustRefuseBudget(name string) bool {
+	if ml.mustRefuse.Load() {
+		return true
+	}
+	for _, b := range ml.budgets {
+		if b.name == name {
+			return b.mustRefuse.Load()
+		}
+	}
+	return false
+}
+
 func getMemUsageChecker(cfg *Config, logger *zap.Logger) (*memUsageChecker, error) {
 	memAllocLimit := uint64(cfg.MemoryLimitMiB) * mibBytes
 	memSpikeLimit := uint64(cfg.MemorySpikeLimitMiB) * mibBytes
@@ -180,7 +338,9 @@ func (ml *MemoryLimiter) CheckMemLimits() {
 
 	ml.logger.Debug("Currently used memory.", memstatToZapField(ms))
 
-	if ml.usageChecker.aboveHardLimit(ms) {
+	// In pressureMode, the Go runtime collects garbage as memory usage approaches the
+	// limit, forcing a GC would only use more CPU.
+	if ml.usageChecker.aboveHardLimit(ms) && !ml.pressureMode {
 		ml.logger.Warn("Memory usage is above hard limit. Forcing a GC.", memstatToZapField(ms))
 		ms = ml.doGCandReadMemStats()
 	}
@@ -199,7 +359,7 @@ func (ml *MemoryLimiter) CheckMemLimits() {
 	if !wasRefusing && mustRefuse {
 		// We are above soft limit, do a GC if it wasn't done recently and see if
 		// it brings memory usage below the soft limit.
-		if time.Since(ml.lastGCDone) > minGCIntervalWhenSoftLimited {
+		if !ml.pressureMode && time.Since(ml.lastGCDone) > minGCIntervalWhenSoftLimited {
 			ml.logger.Info("Memory usage is above soft limit. Forcing a GC.", memstatToZapField(ms))
 			ms = ml.doGCandReadMemStats()
 			// Check the limit again to see if GC helped.
@@ -212,6 +372,18 @@ func (ml *MemoryLimiter) CheckMemLimits() {
 	}
 
 	ml.mustRefuse.Store(mustRefuse)
+
+	for _, b := range ml.budgets {
+		wasRefusing := b.mustRefuse.Load()
+		mustRefuse := ml.usageChecker.aboveBudgetLimit(ms, b.limitPercentage)
+		if wasRefusing && !mustRefuse {
+			ml.logger.Info("Memory usage back within budget. Resuming normal operation.", zap.String("budget", b.name), memstatToZapField(ms))
+		}
+		if !wasRefusing && mustRefuse {
+			ml.logger.Warn("Memory usage is above budget. Refusing data.", zap.String("budget", b.name), memstatToZapField(ms))
+		}
+		b.mustRefuse.Store(mustRefuse)
+	}
 }
 
 type memUsageChecker struct {
@@ -227,6 +399,16 @@ func (d memUsageChecker) aboveHardLimit(ms *runtime.MemStats) bool {
 	return ms.Alloc >= d.memAllocLimit
 }
 
+// budgetLimit returns the memory usage from which the data of a budget with the given
+// percentage of the soft limit is refused.
+func (d memUsageChecker) budgetLimit(percentage uint64) uint64 {
+	return (d.memAllocLimit - d.memSpikeLimit) * percentage / 100
+}
+
+func (d memUsageChecker) aboveBudgetLimit(ms *runtime.MemStats, percentage uint64) bool {
+	return ms.Alloc >= d.budgetLimit(percentage)
+}
+
 func newFixedMemUsageChecker(memAllocLimit, memSpikeLimit uint64) *memUsageChecker {
 	if memSpikeLimit == 0 {
 		// If spike limit is unspecified use 20% of mem limit.
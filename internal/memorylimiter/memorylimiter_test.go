@@ -4,9 +4,14 @@
 package memorylimiter
 
 import (
+	"context"
+	"errors"
 	"runtime"
+	"runtime/debug"
+	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -54,6 +59,206 @@ func TestMemoryPressureResponse(t *testing.T) {
 	assert.True(t, ml.MustRefuse())
 }
 
+func TestMemoryPressureResponseBudgets(t *testing.T) {
+	var currentMemAlloc uint64
+	cfg := &Config{
+		CheckInterval:       time.Second,
+		MemoryLimitMiB:      1000,
+		MemorySpikeLimitMiB: 200,
+		Budgets: map[string]BudgetConfig{
+			"logs":    {LimitPercentage: 50},
+			"metrics": {LimitPercentage: 100},
+		},
+	}
+	ml, err := NewMemoryLimiter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	ml.readMemStatsFn = func(ms *runtime.MemStats) {
+		ms.Alloc = currentMemAlloc
+	}
+
+	// Below the logs budget of 400MiB.
+	currentMemAlloc = 300 * mibBytes
+	ml.CheckMemLimits()
+	assert.False(t, ml.MustRefuse())
+	assert.False(t, ml.MustRefuseBudget("logs"))
+	assert.False(t, ml.MustRefuseBudget("metrics"))
+	assert.False(t, ml.MustRefuseBudget("traces"))
+
+	// Above the logs budget, below the soft limit of 800MiB.
+	currentMemAlloc = 500 * mibBytes
+	ml.CheckMemLimits()
+	assert.False(t, ml.MustRefuse())
+	assert.True(t, ml.MustRefuseBudget("logs"))
+	assert.False(t, ml.MustRefuseBudget("metrics"))
+	assert.False(t, ml.MustRefuseBudget("traces"))
+
+	// Above the soft limit, all data is refused. The GC is not forced since it was done recently.
+	ml.lastGCDone = time.Now()
+	currentMemAlloc = 850 * mibBytes
+	ml.CheckMemLimits()
+	assert.True(t, ml.MustRefuse())
+	assert.True(t, ml.MustRefuseBudget("logs"))
+	assert.True(t, ml.MustRefuseBudget("metrics"))
+	assert.True(t, ml.MustRefuseBudget("traces"))
+
+	currentMemAlloc = 300 * mibBytes
+	ml.CheckMemLimits()
+	assert.False(t, ml.MustRefuse())
+	assert.False(t, ml.MustRefuseBudget("logs"))
+}
+
+type fakeMemoryPressure struct {
+	events   chan struct{}
+	wake     chan struct{}
+	wakeOnce sync.Once
+	closed   atomic.Bool
+}
+
+func newFakeMemoryPressure() *fakeMemoryPressure {
+	return &fakeMemoryPressure{events: make(chan struct{}), wake: make(chan struct{})}
+}
+
+func (p *fakeMemoryPressure) Wait(timeout time.Duration) (bool, error) {
+	var timer <-chan time.Time
+	if timeout >= 0 {
+		timer = time.After(timeout)
+	}
+	select {
+	case <-p.events:
+		return true, nil
+	case <-timer:
+		return false, nil
+	case <-p.wake:
+		return false, nil
+	}
+}
+
+func (p *fakeMemoryPressure) Wake() {
+	p.wakeOnce.Do(func() { close(p.wake) })
+}
+
+func (p *fakeMemoryPressure) Close() error {
+	p.closed.Store(true)
+	return nil
+}
+
+func TestMemoryPressureMode(t *testing.T) {
+	pressure := newFakeMemoryPressure()
+	t.Cleanup(func() {
+		newMemoryPressureFn = func(stall, window time.Duration) (memoryPressure, error) {
+			return iruntime.NewMemoryPressure(stall, window)
+		}
+	})
+	newMemoryPressureFn = func(time.Duration, time.Duration) (memoryPressure, error) {
+		return pressure, nil
+	}
+
+	cfg := &Config{
+		CheckInterval:       time.Hour,
+		Mode:                ModePressure,
+		MemoryLimitMiB:      1000,
+		MemorySpikeLimitMiB: 200,
+	}
+	ml, err := NewMemoryLimiter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	var currentMemAlloc atomic.Uint64
+	ml.readMemStatsFn = func(ms *runtime.MemStats) {
+		ms.Alloc = currentMemAlloc.Load()
+	}
+
+	prevMemoryLimit := debug.SetMemoryLimit(-1)
+	require.NoError(t, ml.Start(context.Background(), nil))
+	assert.Equal(t, int64(1000*mibBytes), debug.SetMemoryLimit(-1))
+
+	// Memory usage is checked when memory pressure is notified.
+	currentMemAlloc.Store(1100 * mibBytes)
+	pressure.events <- struct{}{}
+	assert.Eventually(t, ml.MustRefuse, time.Second, time.Millisecond)
+	// No GC is forced above the hard limit.
+	assert.Zero(t, ml.lastGCDone)
+
+	require.NoError(t, ml.Shutdown(context.Background()))
+	assert.True(t, pressure.closed.Load())
+	assert.Equal(t, prevMemoryLimit, debug.SetMemoryLimit(-1))
+}
+
+func TestMemoryPressureModeFallbackPoll(t *testing.T) {
+	t.Cleanup(func() {
+		newMemoryPressureFn = func(stall, window time.Duration) (memoryPressure, error) {
+			return iruntime.NewMemoryPressure(stall, window)
+		}
+	})
+	newMemoryPressureFn = func(time.Duration, time.Duration) (memoryPressure, error) {
+		return newFakeMemoryPressure(), nil
+	}
+
+	ml, err := NewMemoryLimiter(&Config{
+		CheckInterval:       10 * time.Millisecond,
+		Mode:                ModePressure,
+		MemoryLimitMiB:      1000,
+		MemorySpikeLimitMiB: 200,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	var currentMemAlloc atomic.Uint64
+	ml.readMemStatsFn = func(ms *runtime.MemStats) {
+		ms.Alloc = currentMemAlloc.Load()
+	}
+	require.NoError(t, ml.Start(context.Background(), nil))
+	t.Cleanup(func() { require.NoError(t, ml.Shutdown(context.Background())) })
+
+	// Memory usage is checked every check interval without memory pressure.
+	currentMemAlloc.Store(1100 * mibBytes)
+	assert.Eventually(t, ml.MustRefuse, time.Second, time.Millisecond)
+	currentMemAlloc.Store(300 * mibBytes)
+	assert.Eventually(t, func() bool { return !ml.MustRefuse() }, time.Second, time.Millisecond)
+}
+
+func TestMemoryPressureModeMemoryLimit(t *testing.T) {
+	t.Cleanup(func() {
+		newMemoryPressureFn = func(stall, window time.Duration) (memoryPressure, error) {
+			return iruntime.NewMemoryPressure(stall, window)
+		}
+	})
+	newMemoryPressureFn = func(time.Duration, time.Duration) (memoryPressure, error) {
+		return newFakeMemoryPressure(), nil
+	}
+	newLimiter := func(limitMiB uint32) *MemoryLimiter {
+		ml, err := NewMemoryLimiter(&Config{CheckInterval: time.Hour, Mode: ModePressure, MemoryLimitMiB: limitMiB}, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, ml.Start(context.Background(), nil))
+		return ml
+	}
+
+	prevMemoryLimit := debug.SetMemoryLimit(-1)
+	ml1000 := newLimiter(1000)
+	ml800 := newLimiter(800)
+	// The Go runtime memory limit is process-wide, the lowest limit applies.
+	assert.Equal(t, int64(800*mibBytes), debug.SetMemoryLimit(-1))
+
+	require.NoError(t, ml800.Shutdown(context.Background()))
+	assert.Equal(t, int64(1000*mibBytes), debug.SetMemoryLimit(-1))
+
+	require.NoError(t, ml1000.Shutdown(context.Background()))
+	assert.Equal(t, prevMemoryLimit, debug.SetMemoryLimit(-1))
+}
+
+func TestMemoryPressureModeUnsupported(t *testing.T) {
+	t.Cleanup(func() {
+		newMemoryPressureFn = func(stall, window time.Duration) (memoryPressure, error) {
+			return iruntime.NewMemoryPressure(stall, window)
+		}
+	})
+	newMemoryPressureFn = func(time.Duration, time.Duration) (memoryPressure, error) {
+		return nil, errors.New("unsupported")
+	}
+
+	ml, err := NewMemoryLimiter(&Config{CheckInterval: time.Second, Mode: ModePressure, MemoryLimitMiB: 1000}, zap.NewNop())
+	require.NoError(t, err)
+	err = ml.Start(context.Background(), nil)
+	require.ErrorContains(t, err, `use mode "poll": unsupported`)
+	require.ErrorIs(t, ml.Shutdown(context.Background()), ErrShutdownNotStarted)
+}
+
 func TestGetDecision(t *testing.T) {
 	t.Run("fixed_limit", func(t *testing.T) {
 		d, err := getMemUsageChecker(&Config{MemoryLimitMiB: 100, MemorySpikeLimitMiB: 20}, zap.NewNop())
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"sync/atomic"
@@ -168,7 +169,7 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		return fmt.Errorf("failed to get config: %w", err)
 	}
 
-	if err = cfg.Validate(); err != nil {
+	if err = cfg.validate(col.resolvedConf()); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
@@ -258,7 +259,37 @@ func (col *Collector) DryRun(ctx context.Context) error {
 		return fmt.Errorf("failed to get config: %w", err)
 	}
 
-	return cfg.Validate()
+	return cfg.validate(col.resolvedConf())
+}
+
+// writeTopology validates the configuration and writes the topology of its pipelines
+// to w in the given format, without instantiating any component.
+func (col *Collector) writeTopology(ctx context.Context, w io.Writer, format string) error {
+	factories, err := col.set.Factories()
+	if err != nil {
+		return fmt.Errorf("failed to initialize factories: %w", err)
+	}
+	cfg, err := col.configProvider.Get(ctx, factories)
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+	if err = cfg.validate(col.resolvedConf()); err != nil {
+		return err
+	}
+
+	return service.WriteTopology(w, format, service.Settings{
+		ConnectorsConfigs:   cfg.Connectors,
+		ConnectorsFactories: factories.Connectors,
+	}, cfg.Service)
+}
+
+// resolvedConf returns the configuration the last Config was unmarshaled from,
+// or nil if it is unknown because a custom ConfigProvider is used.
+func (col *Collector) resolvedConf() *confmap.Conf {
+	if cp, ok := col.configProvider.(*configProvider); ok {
+		return cp.resolved
+	}
+	return nil
 }
 
 func newFallbackLogger(options []zap.Option) (*zap.Logger, error) {
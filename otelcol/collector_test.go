@@ -459,7 +459,7 @@ func TestCollectorDryRun(t *testing.T) {
 				Factories:              nopFactories,
 				ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-invalid.yaml")}),
 			},
-			expectedErr: `service::pipelines::traces: references processor "invalid" which is not configured`,
+			expectedErr: `service::pipelines::traces (file:testdata/otelcol-invalid.yaml): references processor "invalid" which is not configured`,
 		},
 	}
 
@@ -38,6 +38,9 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 	}
 	rootCmd.AddCommand(newComponentsCommand(set))
 	rootCmd.AddCommand(newValidateSubCommand(set, flagSet))
+	rootCmd.AddCommand(newGraphSubCommand(set, flagSet))
+	rootCmd.AddCommand(newSchemaCommand(set))
+	rootCmd.AddCommand(newFeatureGatesCommand(set, featuregate.GlobalRegistry(), flagSet))
 	rootCmd.Flags().AddGoFlagSet(flagSet)
 	return rootCmd
 }
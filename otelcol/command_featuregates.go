@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+type featureGateOutput struct {
+	ID           string `yaml:"id"`
+	Enabled      bool   `yaml:"enabled"`
+	Stage        string `yaml:"stage"`
+	FromVersion  string `yaml:"from_version,omitempty"`
+	ToVersion    string `yaml:"to_version,omitempty"`
+	ReferenceURL string `yaml:"reference_url,omitempty"`
+	Description  string `yaml:"description,omitempty"`
+}
+
+// newFeatureGatesCommand constructs a new featuregates command using the given CollectorSettings.
+func newFeatureGatesCommand(set CollectorSettings, reg *featuregate.Registry, flagSet *flag.FlagSet) *cobra.Command {
+	var check bool
+	var knownOverdue []string
+	featureGatesCmd := &cobra.Command{
+		Use:   "featuregates",
+		Short: "Outputs the feature gates of this collector distribution",
+		Long:  "Outputs the feature gates of this collector distribution including their stage, versions and reference URL. The output format is not stable and can change between releases.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if check {
+				if err := reg.CheckLifecycle(set.BuildInfo.Version, knownOverdue...); err != nil {
+					return err
+				}
+			}
+			var gates []featureGateOutput
+			reg.VisitAll(func(g *featuregate.Gate) {
+				gates = append(gates, featureGateOutput{
+					ID:           g.ID(),
+					Enabled:      g.IsEnabled(),
+					Stage:        g.Stage().String(),
+					FromVersion:  g.FromVersion(),
+					ToVersion:    g.ToVersion(),
+					ReferenceURL: g.ReferenceURL(),
+					Description:  g.Description(),
+				})
+			})
+			yamlData, err := yaml.Marshal(gates)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), string(yamlData))
+			return nil
+		},
+	}
+	featureGatesCmd.Flags().AddGoFlagSet(flagSet)
+	featureGatesCmd.Flags().BoolVar(&check, "check", false, "Fail if a feature gate outlived its removal version, according to the version of this collector distribution")
+	featureGatesCmd.Flags().StringSliceVar(&knownOverdue, "known-overdue", nil, "Comma-delimited list of feature gates known to have outlived their removal version, which don't fail the check")
+	return featureGatesCmd
+}
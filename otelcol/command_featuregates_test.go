@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func newTestFeatureGatesRegistry() *featuregate.Registry {
+	reg := featuregate.NewRegistry()
+	reg.MustRegister("test.alpha", featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("Alpha gate"),
+		featuregate.WithRegisterFromVersion("v0.100.0"),
+		featuregate.WithRegisterReferenceURL("https://example.com/issues/1"))
+	reg.MustRegister("test.stable", featuregate.StageStable,
+		featuregate.WithRegisterFromVersion("v0.90.0"),
+		featuregate.WithRegisterToVersion("v0.110.0"))
+	return reg
+}
+
+func TestFeatureGatesCommand(t *testing.T) {
+	reg := newTestFeatureGatesRegistry()
+	cmd := newFeatureGatesCommand(CollectorSettings{BuildInfo: component.BuildInfo{Version: "0.120.0"}}, reg, flags(reg))
+	cmd.SetArgs([]string{"--feature-gates", "test.alpha"})
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, `- id: test.alpha
+  enabled: true
+  stage: Alpha
+  from_version: v0.100.0
+  reference_url: https://example.com/issues/1
+  description: Alpha gate
+- id: test.stable
+  enabled: true
+  stage: Stable
+  from_version: v0.90.0
+  to_version: v0.110.0
+`, out.String())
+}
+
+func TestFeatureGatesCommandCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		args    []string
+		wantErr string
+	}{
+		{name: "current", version: "0.110.0"},
+		{name: "overdue", version: "0.111.0-dev", wantErr: `feature gate "test.stable" outlived its removal version v0.110.0, current version is v0.111.0`},
+		{name: "known overdue", version: "0.111.0-dev", args: []string{"--known-overdue", "test.stable"}},
+		{name: "invalid version", version: "latest", wantErr: `invalid current version "latest"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := newTestFeatureGatesRegistry()
+			cmd := newFeatureGatesCommand(CollectorSettings{BuildInfo: component.BuildInfo{Version: tt.version}}, reg, flags(reg))
+			cmd.SetArgs(append([]string{"--check"}, tt.args...))
+			cmd.SetOut(&bytes.Buffer{})
+			err := cmd.Execute()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/service"
+)
+
+// newGraphSubCommand constructs a new graph sub command using the given CollectorSettings.
+func newGraphSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var format string
+	graphCmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Outputs the topology of the configured pipelines",
+		Long:  "Validates the config and outputs the graph of receivers, processors, connectors and exporters of its pipelines, without running the collector. The output format is not stable and can change between releases.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := updateSettingsUsingFlags(&set, flagSet); err != nil {
+				return err
+			}
+			col, err := NewCollector(set)
+			if err != nil {
+				return err
+			}
+			return col.writeTopology(cmd.Context(), cmd.OutOrStdout(), format)
+		},
+	}
+	graphCmd.Flags().AddGoFlagSet(flagSet)
+	graphCmd.Flags().StringVar(&format, "format", "dot", fmt.Sprintf("Output format, one of: %s", strings.Join(service.TopologyFormats(), ", ")))
+	return graphCmd
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+const graphTestConfig = `receivers:
+  nop:
+exporters:
+  nop:
+service:
+  pipelines:
+    traces:
+      receivers: [nop]
+      exporters: [nop]
+`
+
+func newYAMLGraphSubCommand(yamlBytes string, args ...string) *cobra.Command {
+	yamlProvider := newFakeProvider("yaml", func(_ context.Context, _ string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		return confmap.NewRetrievedFromYAML([]byte(yamlBytes))
+	})
+	cmd := newGraphSubCommand(CollectorSettings{Factories: nopFactories, ConfigProviderSettings: ConfigProviderSettings{
+		ResolverSettings: confmap.ResolverSettings{
+			URIs:              []string{"yaml:config"},
+			DefaultScheme:     "yaml",
+			ProviderFactories: []confmap.ProviderFactory{yamlProvider},
+		},
+	}}, flags(featuregate.NewRegistry()))
+	cmd.SetArgs(args)
+	return cmd
+}
+
+func TestGraphSubCommandNoConfig(t *testing.T) {
+	cmd := newGraphSubCommand(CollectorSettings{Factories: nopFactories}, flags(featuregate.GlobalRegistry()))
+	err := cmd.Execute()
+	require.ErrorContains(t, err, "at least one config flag must be provided")
+}
+
+func TestGraphSubCommand(t *testing.T) {
+	cmd := newYAMLGraphSubCommand(graphTestConfig)
+	out := bytes.NewBufferString("")
+	cmd.SetOut(out)
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, `digraph pipelines {
+  rankdir=LR;
+  subgraph cluster_0 {
+    label="traces";
+    "capabilities/traces" [label="capabilities"];
+    "fanout/traces" [label="fanout"];
+  }
+  "exporter/traces/nop" [label="exporter: nop"];
+  "receiver/traces/nop" [label="receiver: nop"];
+  "capabilities/traces" -> "fanout/traces";
+  "fanout/traces" -> "exporter/traces/nop";
+  "receiver/traces/nop" -> "capabilities/traces";
+}
+`, out.String())
+}
+
+func TestGraphSubCommandFormat(t *testing.T) {
+	cmd := newYAMLGraphSubCommand(graphTestConfig, "--format", "mermaid")
+	out := bytes.NewBufferString("")
+	cmd.SetOut(out)
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "flowchart LR\n")
+
+	cmd = newYAMLGraphSubCommand(graphTestConfig, "--format", "svg")
+	cmd.SetOut(bytes.NewBufferString(""))
+	require.EqualError(t, cmd.Execute(), `unsupported topology format "svg", must be one of [dot mermaid json]`)
+}
+
+func TestGraphSubCommandInvalidConfig(t *testing.T) {
+	cmd := newYAMLGraphSubCommand(`receivers:
+  nop:
+exporters:
+  nop:
+service:
+  pipelines:
+    traces:
+      receivers: [nop]
+      exporters: [nop/missing]
+`)
+	cmd.SetOut(bytes.NewBufferString(""))
+	require.ErrorContains(t, cmd.Execute(), `references exporter "nop/missing" which is not configured`)
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/otelcol/internal/configschema"
+	"go.opentelemetry.io/collector/service"
+)
+
+// newSchemaCommand constructs a new schema command using the given CollectorSettings.
+func newSchemaCommand(set CollectorSettings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Outputs the JSON Schema of the configuration of this collector distribution",
+		Long:  "Outputs a JSON Schema describing the configuration accepted by this collector distribution, derived from the default configuration of every available component. The output format is not stable and can change between releases.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			factories, err := set.Factories()
+			if err != nil {
+				return fmt.Errorf("failed to initialize factories: %w", err)
+			}
+
+			components := map[string]map[component.Type]component.Config{
+				"receivers":  defaultConfigs(factories.Receivers),
+				"processors": defaultConfigs(factories.Processors),
+				"exporters":  defaultConfigs(factories.Exporters),
+				"connectors": defaultConfigs(factories.Connectors),
+				"extensions": defaultConfigs(factories.Extensions),
+			}
+			schema := configschema.Distribution(set.BuildInfo.Description, components, service.Config{})
+
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(schema)
+		},
+	}
+}
+
+func defaultConfigs[T component.Factory](factories map[component.Type]T) map[component.Type]component.Config {
+	cfgs := make(map[component.Type]component.Config, len(factories))
+	for typ, factory := range factories {
+		cfgs[typ] = factory.CreateDefaultConfig()
+	}
+	return cfgs
+}
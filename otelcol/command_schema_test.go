@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestNewSchemaSubCommand(t *testing.T) {
+	set := CollectorSettings{
+		BuildInfo:              component.NewDefaultBuildInfo(),
+		Factories:              nopFactories,
+		ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-nop.yaml")}),
+	}
+	cmd := NewCommand(set)
+	cmd.SetArgs([]string{"schema"})
+
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, cmd.Execute())
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(b.Bytes(), &schema))
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+
+	props := schema["properties"].(map[string]any)
+	for _, section := range []string{"receivers", "processors", "exporters", "connectors", "extensions", "service"} {
+		assert.Contains(t, props, section)
+	}
+	defs := schema["$defs"].(map[string]any)
+	for _, def := range []string{"receivers.nop", "receivers.nop_logs", "processors.nop", "exporters.nop", "connectors.nop", "extensions.nop"} {
+		assert.Contains(t, defs, def)
+	}
+}
+
+func TestNewSchemaSubCommandFactoriesError(t *testing.T) {
+	set := CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: func() (Factories, error) { return Factories{}, assert.AnError },
+	}
+	cmd := NewCommand(set)
+	cmd.SetArgs([]string{"schema"})
+	require.ErrorIs(t, cmd.Execute(), assert.AnError)
+}
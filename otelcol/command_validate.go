@@ -5,12 +5,19 @@ package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
 	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/confmap"
 )
 
 // newValidateSubCommand constructs a new validate sub command using the given CollectorSettings.
 func newValidateSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var verbose bool
 	validateCmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validates the config without running the collector",
@@ -23,9 +30,36 @@ func newValidateSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.
 			if err != nil {
 				return err
 			}
-			return col.DryRun(cmd.Context())
+			err = col.DryRun(cmd.Context())
+			if conf := col.resolvedConf(); verbose && conf != nil {
+				if printErr := printOrigins(cmd.OutOrStdout(), conf); printErr != nil {
+					return printErr
+				}
+			}
+			return err
 		},
 	}
 	validateCmd.Flags().AddGoFlagSet(flagSet)
+	validateCmd.Flags().BoolVar(&verbose, "verbose", false, "Print every resolved configuration key along with the location where it was defined")
 	return validateCmd
 }
+
+// printOrigins writes the keys of the resolved configuration sorted by name,
+// along with the location where they were defined. Values are not printed
+// since they may contain secrets.
+func printOrigins(w io.Writer, conf *confmap.Conf) error {
+	keys := conf.AllKeys()
+	sort.Strings(keys)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, key := range keys {
+		origin := "unknown"
+		if o, ok := conf.Origin(key); ok {
+			origin = o.String()
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\n", key, origin); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
@@ -4,10 +4,13 @@
 package otelcol
 
 import (
+	"bytes"
 	"context"
 	"path/filepath"
 	"testing"
 
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/confmap"
@@ -35,3 +38,53 @@ func TestValidateSubCommandInvalidComponents(t *testing.T) {
 	err := cmd.Execute()
 	require.ErrorContains(t, err, "unknown type: \"nosuchprocessor\"")
 }
+
+func newYAMLValidateSubCommand(t *testing.T, yamlBytes string, args ...string) *cobra.Command {
+	yamlProvider := newFakeProvider("yaml", func(_ context.Context, _ string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		return confmap.NewRetrievedFromYAML([]byte(yamlBytes))
+	})
+	cmd := newValidateSubCommand(CollectorSettings{Factories: nopFactories, ConfigProviderSettings: ConfigProviderSettings{
+		ResolverSettings: confmap.ResolverSettings{
+			URIs:              []string{"yaml:config"},
+			DefaultScheme:     "yaml",
+			ProviderFactories: []confmap.ProviderFactory{yamlProvider},
+		},
+	}}, flags(featuregate.NewRegistry()))
+	cmd.SetArgs(args)
+	return cmd
+}
+
+func TestValidateSubCommandVerbose(t *testing.T) {
+	cmd := newYAMLValidateSubCommand(t, `receivers:
+  nop:
+exporters:
+  nop:
+service:
+  pipelines:
+    traces:
+      receivers: [nop]
+      exporters: [nop]
+`, "--verbose")
+	out := bytes.NewBufferString("")
+	cmd.SetOut(out)
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, `exporters::nop                         yaml:config:4:3
+receivers::nop                         yaml:config:2:3
+service::pipelines::traces::exporters  yaml:config:9:7
+service::pipelines::traces::receivers  yaml:config:8:7
+`, out.String())
+}
+
+func TestValidateSubCommandErrorOrigin(t *testing.T) {
+	cmd := newYAMLValidateSubCommand(t, `receivers:
+  nop:
+exporters:
+  nop:
+service:
+  pipelines:
+    traces:
+      receivers: [nop]
+      exporters: [nop/missing]
+`)
+	require.EqualError(t, cmd.Execute(), `service::pipelines::traces (yaml:config:9:7): references exporter "nop/missing" which is not configured`)
+}
@@ -6,8 +6,10 @@ package otelcol // import "go.opentelemetry.io/collector/otelcol"
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/service"
 )
 
@@ -43,6 +45,12 @@ type Config struct {
 // invalid cases that we currently don't check for but which we may want to add in
 // the future (e.g. disallowing receiving and exporting on the same endpoint).
 func (cfg *Config) Validate() error {
+	return cfg.validate(nil)
+}
+
+// validate is like Validate, but reports where the invalid values were defined
+// if conf, the configuration cfg was unmarshaled from, is not nil.
+func (cfg *Config) validate(conf *confmap.Conf) error {
 	// There must be at least one property set in the configuration	file.
 	if len(cfg.Receivers) == 0 && len(cfg.Exporters) == 0 && len(cfg.Processors) == 0 && len(cfg.Connectors) == 0 && len(cfg.Extensions) == 0 {
 		return errEmptyConfigurationFile
@@ -57,7 +65,7 @@ func (cfg *Config) Validate() error {
 	// Validate the receiver configuration.
 	for recvID, recvCfg := range cfg.Receivers {
 		if err := component.ValidateConfig(recvCfg); err != nil {
-			return fmt.Errorf("receivers::%s: %w", recvID, err)
+			return fmt.Errorf("receivers::%s%s: %w", recvID, origin(conf, "receivers", recvID.String()), err)
 		}
 	}
 
@@ -70,39 +78,39 @@ func (cfg *Config) Validate() error {
 	// Validate the exporter configuration.
 	for expID, expCfg := range cfg.Exporters {
 		if err := component.ValidateConfig(expCfg); err != nil {
-			return fmt.Errorf("exporters::%s: %w", expID, err)
+			return fmt.Errorf("exporters::%s%s: %w", expID, origin(conf, "exporters", expID.String()), err)
 		}
 	}
 
 	// Validate the processor configuration.
 	for procID, procCfg := range cfg.Processors {
 		if err := component.ValidateConfig(procCfg); err != nil {
-			return fmt.Errorf("processors::%s: %w", procID, err)
+			return fmt.Errorf("processors::%s%s: %w", procID, origin(conf, "processors", procID.String()), err)
 		}
 	}
 
 	// Validate the connector configuration.
 	for connID, connCfg := range cfg.Connectors {
 		if err := component.ValidateConfig(connCfg); err != nil {
-			return fmt.Errorf("connectors::%s: %w", connID, err)
+			return fmt.Errorf("connectors::%s%s: %w", connID, origin(conf, "connectors", connID.String()), err)
 		}
 
 		if _, ok := cfg.Exporters[connID]; ok {
-			return fmt.Errorf("connectors::%s: ambiguous ID: Found both %q exporter and %q connector. "+
+			return fmt.Errorf("connectors::%s%s: ambiguous ID: Found both %q exporter and %q connector. "+
 				"Change one of the components' IDs to eliminate ambiguity (e.g. rename %q connector to %q)",
-				connID, connID, connID, connID, connID.String()+"/connector")
+				connID, origin(conf, "connectors", connID.String()), connID, connID, connID, connID.String()+"/connector")
 		}
 		if _, ok := cfg.Receivers[connID]; ok {
-			return fmt.Errorf("connectors::%s: ambiguous ID: Found both %q receiver and %q connector. "+
+			return fmt.Errorf("connectors::%s%s: ambiguous ID: Found both %q receiver and %q connector. "+
 				"Change one of the components' IDs to eliminate ambiguity (e.g. rename %q connector to %q)",
-				connID, connID, connID, connID, connID.String()+"/connector")
+				connID, origin(conf, "connectors", connID.String()), connID, connID, connID, connID.String()+"/connector")
 		}
 	}
 
 	// Validate the extension configuration.
 	for extID, extCfg := range cfg.Extensions {
 		if err := component.ValidateConfig(extCfg); err != nil {
-			return fmt.Errorf("extensions::%s: %w", extID, err)
+			return fmt.Errorf("extensions::%s%s: %w", extID, origin(conf, "extensions", extID.String()), err)
 		}
 	}
 
@@ -114,7 +122,7 @@ func (cfg *Config) Validate() error {
 	for _, ref := range cfg.Service.Extensions {
 		// Check that the name referenced in the Service extensions exists in the top-level extensions.
 		if cfg.Extensions[ref] == nil {
-			return fmt.Errorf("service::extensions: references extension %q which is not configured", ref)
+			return fmt.Errorf("service::extensions%s: references extension %q which is not configured", origin(conf, "service", "extensions"), ref)
 		}
 	}
 
@@ -130,14 +138,14 @@ func (cfg *Config) Validate() error {
 			if _, ok := cfg.Connectors[ref]; ok {
 				continue
 			}
-			return fmt.Errorf("service::pipelines::%s: references receiver %q which is not configured", pipelineID.String(), ref)
+			return fmt.Errorf("service::pipelines::%s%s: references receiver %q which is not configured", pipelineID.String(), origin(conf, "service", "pipelines", pipelineID.String(), "receivers"), ref)
 		}
 
 		// Validate pipeline processor name references.
 		for _, ref := range pipeline.Processors {
 			// Check that the name referenced in the pipeline's processors exists in the top-level processors.
 			if cfg.Processors[ref] == nil {
-				return fmt.Errorf("service::pipelines::%s: references processor %q which is not configured", pipelineID.String(), ref)
+				return fmt.Errorf("service::pipelines::%s%s: references processor %q which is not configured", pipelineID.String(), origin(conf, "service", "pipelines", pipelineID.String(), "processors"), ref)
 			}
 		}
 
@@ -150,8 +158,21 @@ func (cfg *Config) Validate() error {
 			if _, ok := cfg.Connectors[ref]; ok {
 				continue
 			}
-			return fmt.Errorf("service::pipelines::%s: references exporter %q which is not configured", pipelineID.String(), ref)
+			return fmt.Errorf("service::pipelines::%s%s: references exporter %q which is not configured", pipelineID.String(), origin(conf, "service", "pipelines", pipelineID.String(), "exporters"), ref)
 		}
 	}
 	return nil
 }
+
+// origin returns where the key formed by joining the given path segments was defined,
+// formatted to be added to error messages, or an empty string if unknown.
+func origin(conf *confmap.Conf, path ...string) string {
+	if conf == nil {
+		return ""
+	}
+	o, ok := conf.Origin(strings.Join(path, confmap.KeyDelimiter))
+	if !ok {
+		return ""
+	}
+	return " (" + o.String() + ")"
+}
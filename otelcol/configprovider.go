@@ -46,6 +46,9 @@ type ConfigProvider interface {
 
 type configProvider struct {
 	mapResolver *confmap.Resolver
+
+	// resolved is the configuration returned by the last successful call to Get.
+	resolved *confmap.Conf
 }
 
 var _ ConfigProvider = (*configProvider)(nil)
@@ -83,6 +86,7 @@ func (cm *configProvider) Get(ctx context.Context, factories Factories) (*Config
 	if cfg, err = unmarshal(conf, factories); err != nil {
 		return nil, fmt.Errorf("cannot unmarshal the configuration: %w", err)
 	}
+	cm.resolved = conf
 
 	return &Config{
 		Receivers:  cfg.Receivers.Configs(),
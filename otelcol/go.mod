@@ -155,6 +155,8 @@ replace go.opentelemetry.io/collector/featuregate => ../featuregate
 
 replace go.opentelemetry.io/collector/config/configretry => ../config/configretry
 
+replace go.opentelemetry.io/collector/config/confignet => ../config/confignet
+
 replace go.opentelemetry.io/collector/config/confighttp => ../config/confighttp
 
 replace go.opentelemetry.io/collector/config/internal => ../config/internal
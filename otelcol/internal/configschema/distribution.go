@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema // import "go.opentelemetry.io/collector/otelcol/internal/configschema"
+
+import (
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Distribution returns the schema of a full collector configuration file.
+//
+// components maps each top-level section (e.g. "receivers") to the default
+// configuration of every component type available in that section, and
+// service is the value used to describe the "service" section.
+func Distribution(title string, components map[string]map[component.Type]component.Config, service any) *Schema {
+	root := &Schema{
+		Schema:               Draft,
+		Title:                title,
+		Type:                 "object",
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+		Defs:                 map[string]*Schema{},
+	}
+
+	for section, cfgs := range components {
+		sectionSchema := &Schema{
+			Type:                 "object",
+			PatternProperties:    map[string]*Schema{},
+			AdditionalProperties: false,
+		}
+		for typ, cfg := range cfgs {
+			def := section + "." + typ.String()
+			root.Defs[def] = FromConfig(cfg)
+			// Components are referenced as "type" or "type/name", and may be declared without a body.
+			sectionSchema.PatternProperties["^"+regexp.QuoteMeta(typ.String())+"(/.+)?$"] = &Schema{
+				AnyOf: []*Schema{{Ref: "#/$defs/" + def}, {Type: "null"}},
+			}
+		}
+		root.Properties[section] = sectionSchema
+	}
+
+	root.Properties["service"] = FromConfig(service)
+	return root
+}
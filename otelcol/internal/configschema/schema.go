@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema // import "go.opentelemetry.io/collector/otelcol/internal/configschema"
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect emitted by this package.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or sub-schema.
+// Only the keywords needed to describe collector configuration are supported.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	PatternProperties    map[string]*Schema `json:"patternProperties,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// FromConfig returns the schema describing the given configuration value.
+// The schema is derived from the mapstructure tags of the value's type, and
+// non-zero field values of cfg are reported as defaults.
+func FromConfig(cfg any) *Schema {
+	if cfg == nil {
+		return &Schema{}
+	}
+	g := &generator{visiting: map[reflect.Type]bool{}}
+	v := reflect.ValueOf(cfg)
+	// The configuration itself is described as an object, its nullability is up to the caller.
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	return g.schemaFor(v)
+}
+
+type generator struct {
+	// visiting holds the struct types currently being expanded, to stop on recursive types.
+	visiting map[reflect.Type]bool
+}
+
+func (g *generator) schemaFor(v reflect.Value) *Schema {
+	t := v.Type()
+	switch {
+	case t == durationType:
+		s := &Schema{Type: "string", Description: "Duration, e.g. \"1s\" or \"500ms\"."}
+		if !v.IsZero() {
+			s.Default = time.Duration(v.Int()).String()
+		}
+		return s
+	case t == timeType:
+		return &Schema{Type: "string"}
+	case t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface && reflect.PointerTo(t).Implements(textUnmarshalerType):
+		// confmap decodes strings into encoding.TextUnmarshaler types.
+		s := &Schema{Type: "string"}
+		if !v.IsZero() && t.Implements(textMarshalerType) {
+			if text, err := v.Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+				s.Default = string(text)
+			}
+		}
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		// confmap decodes null into nil pointers, e.g. for "grpc:" in the OTLP receiver protocols.
+		elem := v
+		if v.IsNil() {
+			elem = reflect.New(t.Elem())
+		}
+		return &Schema{AnyOf: []*Schema{g.schemaFor(elem.Elem()), {Type: "null"}}}
+	case reflect.Interface:
+		if v.IsNil() {
+			return &Schema{}
+		}
+		return g.schemaFor(v.Elem())
+	case reflect.Bool:
+		s := &Schema{Type: "boolean"}
+		if v.Bool() {
+			s.Default = true
+		}
+		return s
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s := &Schema{Type: "integer"}
+		if !v.IsZero() {
+			s.Default = v.Int()
+		}
+		return s
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		zero := 0
+		s := &Schema{Type: "integer", Minimum: &zero}
+		if !v.IsZero() {
+			s.Default = v.Uint()
+		}
+		return s
+	case reflect.Float32, reflect.Float64:
+		s := &Schema{Type: "number"}
+		if !v.IsZero() {
+			s.Default = v.Float()
+		}
+		return s
+	case reflect.String:
+		s := &Schema{Type: "string"}
+		if !v.IsZero() {
+			s.Default = v.String()
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaFor(reflect.New(t.Elem()).Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(reflect.New(t.Elem()).Elem())}
+	case reflect.Struct:
+		if g.visiting[t] {
+			return &Schema{Type: "object"}
+		}
+		g.visiting[t] = true
+		defer delete(g.visiting, t)
+
+		s := &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: false}
+		g.addFields(s, v)
+		return s
+	default:
+		// Channels, funcs and other kinds cannot be expressed in configuration.
+		return &Schema{}
+	}
+}
+
+// addFields adds the properties of the struct value v to s, following the
+// same rules used by confmap to decode configuration into structs.
+func (g *generator) addFields(s *Schema, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		// Exported fields of squashed embedded structs are decoded even if the embedded type is unexported.
+		if name == "-" || (!field.IsExported() && !(field.Anonymous && hasOption(opts, "squash"))) {
+			continue
+		}
+
+		fv := v.Field(i)
+		switch {
+		case hasOption(opts, "squash"):
+			for fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					fv = reflect.New(fv.Type().Elem()).Elem()
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				g.addFields(s, fv)
+			}
+			continue
+		case hasOption(opts, "remain"):
+			s.AdditionalProperties = true
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		s.Properties[name] = g.schemaFor(fv)
+	}
+}
+
+func hasOption(opts string, opt string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,307 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+type embedded struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+type nested struct {
+	Enabled bool              `mapstructure:"enabled"`
+	Headers map[string]string `mapstructure:"headers"`
+}
+
+type recursive struct {
+	Next *recursive `mapstructure:"next"`
+}
+
+type testConfig struct {
+	embedded `mapstructure:",squash"`
+
+	Timeout   time.Duration `mapstructure:"timeout"`
+	Retries   uint          `mapstructure:"retries"`
+	Ratio     float64       `mapstructure:"ratio"`
+	ID        component.ID  `mapstructure:"id"`
+	Nested    *nested       `mapstructure:"nested"`
+	Tags      []string      `mapstructure:"tags"`
+	Recursive recursive     `mapstructure:"recursive"`
+	Ignored   string        `mapstructure:"-"`
+	NoTag     int
+	internal  string
+}
+
+func TestFromConfig(t *testing.T) {
+	cfg := &testConfig{
+		embedded: embedded{Endpoint: "localhost:4317"},
+		Timeout:  5 * time.Second,
+		Retries:  3,
+		ID:       component.MustNewID("nop"),
+		internal: "unused",
+	}
+
+	got, err := json.Marshal(FromConfig(cfg))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"endpoint": {"type": "string", "default": "localhost:4317"},
+			"timeout": {"type": "string", "description": "Duration, e.g. \"1s\" or \"500ms\".", "default": "5s"},
+			"retries": {"type": "integer", "minimum": 0, "default": 3},
+			"ratio": {"type": "number"},
+			"id": {"type": "string", "default": "nop"},
+			"nested": {
+				"anyOf": [
+					{
+						"type": "object",
+						"additionalProperties": false,
+						"properties": {
+							"enabled": {"type": "boolean"},
+							"headers": {"type": "object", "additionalProperties": {"type": "string"}}
+						}
+					},
+					{"type": "null"}
+				]
+			},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"recursive": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"next": {"anyOf": [{"type": "object"}, {"type": "null"}]}
+				}
+			},
+			"notag": {"type": "integer"}
+		}
+	}`, string(got))
+}
+
+func TestFromConfigNil(t *testing.T) {
+	assert.Equal(t, &Schema{}, FromConfig(nil))
+}
+
+func TestDistribution(t *testing.T) {
+	components := map[string]map[component.Type]component.Config{
+		"receivers": {component.MustNewType("nop"): &struct{}{}},
+	}
+	got, err := json.Marshal(Distribution("test", components, struct {
+		Pipelines map[string]any `mapstructure:"pipelines"`
+	}{}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "test",
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"receivers": {
+				"type": "object",
+				"additionalProperties": false,
+				"patternProperties": {
+					"^nop(/.+)?$": {"anyOf": [{"$ref": "#/$defs/receivers.nop"}, {"type": "null"}]}
+				}
+			},
+			"service": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"pipelines": {"type": "object", "additionalProperties": {}}
+				}
+			}
+		},
+		"$defs": {
+			"receivers.nop": {"type": "object", "additionalProperties": false}
+		}
+	}`, string(got))
+}
+
+type corsConfig struct {
+	AllowedOrigins []string `mapstructure:"allowed_origins"`
+}
+
+type grpcConfig struct {
+	Endpoint          string `mapstructure:"endpoint"`
+	MaxRecvMsgSizeMiB int    `mapstructure:"max_recv_msg_size_mib"`
+}
+
+type httpConfig struct {
+	Endpoint string      `mapstructure:"endpoint"`
+	CORS     *corsConfig `mapstructure:"cors"`
+}
+
+type protocolsConfig struct {
+	GRPC *grpcConfig `mapstructure:"grpc"`
+	HTTP *httpConfig `mapstructure:"http"`
+}
+
+// receiverConfig has the layout of the configuration of the OTLP receiver.
+type receiverConfig struct {
+	Protocols protocolsConfig `mapstructure:"protocols"`
+}
+
+func TestDistributionNestedConfig(t *testing.T) {
+	schema := Distribution("test", map[string]map[component.Type]component.Config{
+		"receivers": {component.MustNewType("otlp"): &receiverConfig{
+			Protocols: protocolsConfig{
+				GRPC: &grpcConfig{Endpoint: "localhost:4317", MaxRecvMsgSizeMiB: 4},
+				HTTP: &httpConfig{Endpoint: "localhost:4318"},
+			},
+		}},
+	}, struct{}{})
+
+	tests := []struct {
+		name string
+		yaml string
+		errs []string
+	}{
+		{
+			name: "default",
+			yaml: `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+`,
+		},
+		{
+			name: "endpoints",
+			yaml: `
+receivers:
+  otlp/2:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+        max_recv_msg_size_mib: 16
+      http:
+        endpoint: 0.0.0.0:4318
+        cors:
+          allowed_origins: [https://*.example.com]
+`,
+		},
+		{
+			name: "invalid",
+			yaml: `
+receivers:
+  otlp:
+    protocols:
+      grcp:
+      http:
+        endpoint: 4318
+`,
+			errs: []string{
+				"receivers.otlp.protocols: unknown property grcp",
+				"receivers.otlp.protocols.http.endpoint: expected string, got int",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retrieved, err := confmap.NewRetrievedFromYAML([]byte(tt.yaml))
+			require.NoError(t, err)
+			raw, err := retrieved.AsRaw()
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.errs, validate(schema, schema, raw, ""))
+		})
+	}
+}
+
+// validate returns the errors of the validation of value against s, supporting the
+// keywords emitted by this package. root holds the definitions referenced by s.
+func validate(root, s *Schema, value any, path string) []string {
+	if s.Ref != "" {
+		return validate(root, root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")], value, path)
+	}
+	if len(s.AnyOf) > 0 {
+		var errs []string
+		for _, alt := range s.AnyOf {
+			if alt.Type == "null" && value != nil {
+				// Only report why the value doesn't match the non-null alternatives.
+				continue
+			}
+			altErrs := validate(root, alt, value, path)
+			if len(altErrs) == 0 {
+				return nil
+			}
+			errs = append(errs, altErrs...)
+		}
+		return errs
+	}
+	if s.Type != "" && typeOf(value) != s.Type && (s.Type != "number" || typeOf(value) != "integer") {
+		return []string{fmt.Sprintf("%s: expected %s, got %T", path, s.Type, value)}
+	}
+	var errs []string
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			itemPath := strings.TrimPrefix(path+"."+key, ".")
+			if prop, ok := s.Properties[key]; ok {
+				errs = append(errs, validate(root, prop, item, itemPath)...)
+				continue
+			}
+			matched := false
+			for pattern, prop := range s.PatternProperties {
+				if regexp.MustCompile(pattern).MatchString(key) {
+					matched = true
+					errs = append(errs, validate(root, prop, item, itemPath)...)
+				}
+			}
+			if matched {
+				continue
+			}
+			switch additional := s.AdditionalProperties.(type) {
+			case bool:
+				if !additional {
+					errs = append(errs, fmt.Sprintf("%s: unknown property %s", path, key))
+				}
+			case *Schema:
+				errs = append(errs, validate(root, additional, item, itemPath)...)
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				errs = append(errs, validate(root, s.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return errs
+}
+
+// typeOf returns the JSON Schema type of a value decoded from YAML.
+func typeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -154,3 +154,22 @@ func TestUnmarshal_LoggingExporter(t *testing.T) {
 	err := cfgs.Unmarshal(conf)
 	assert.ErrorContains(t, err, "the logging exporter has been deprecated, use the debug exporter instead")
 }
+
+func TestUnmarshal_UnusedKeyPath(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"nop/my": map[string]any{
+			"unknown_section": "value",
+		},
+	})
+	cfgs := NewConfigs(map[component.Type]component.Factory{
+		nopType: receivertest.NewNopFactory(),
+	})
+	err := cfgs.Unmarshal(conf)
+	require.ErrorContains(t, err, "error reading configuration for \"nop/my\"")
+	require.ErrorContains(t, err, "'' has invalid keys: unknown_section (at nop/my::unknown_section)")
+
+	var uke *confmap.UnusedKeysError
+	require.ErrorAs(t, err, &uke)
+	require.Len(t, uke.Keys, 1)
+	assert.Equal(t, "nop/my::unknown_section", uke.Keys[0].Path)
+}
@@ -70,6 +70,21 @@ func TestUnmarshalUnknownTopLevel(t *testing.T) {
 	assert.ErrorContains(t, err, "'' has invalid keys: unknown_section")
 }
 
+func TestUnmarshalUnknownComponentKey(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"receivers": map[string]any{
+			"nop": map[string]any{
+				"unknown_section": nil,
+			},
+		},
+	})
+	_, err = unmarshal(conf, factories)
+	assert.ErrorContains(t, err, "'' has invalid keys: unknown_section (at receivers::nop::unknown_section)")
+}
+
 func TestPipelineConfigUnmarshalError(t *testing.T) {
 	testCases := []struct {
 		// test case name (also file name containing config yaml)
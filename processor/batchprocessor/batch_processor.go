@@ -110,7 +110,7 @@ type batch[T any] interface {
 }
 
 // newBatchProcessor returns a new batch processor component.
-func newBatchProcessor[T any](set processor.Settings, cfg *Config, batchFunc func() batch[T]) (*batchProcessor[T], error) {
+func newBatchProcessor[T any](set processor.Settings, cfg *Config, batchFunc func() batch[T], groupFunc func(T, []string) []resourceGroup[T]) (*batchProcessor[T], error) {
 	// use lower-case, to be consistent with http/2 headers.
 	mks := make([]string, len(cfg.MetadataKeys))
 	for i, k := range cfg.MetadataKeys {
@@ -126,16 +126,18 @@ func newBatchProcessor[T any](set processor.Settings, cfg *Config, batchFunc fun
 		batchFunc:        batchFunc,
 		shutdownC:        make(chan struct{}, 1),
 	}
-	if len(mks) == 0 {
+	if len(mks) == 0 && len(cfg.ResourceAttributeKeys) == 0 {
 		bp.batcher = &singleShardBatcher[T]{
 			processor: bp,
 			single:    bp.newShard(nil),
 		}
 	} else {
 		bp.batcher = &multiShardBatcher[T]{
-			metadataKeys:  mks,
-			metadataLimit: int(cfg.MetadataCardinalityLimit),
-			processor:     bp,
+			metadataKeys:          mks,
+			resourceAttributeKeys: cfg.ResourceAttributeKeys,
+			groupFunc:             groupFunc,
+			metadataLimit:         int(cfg.MetadataCardinalityLimit),
+			processor:             bp,
 		}
 	}
 
@@ -291,7 +293,7 @@ func (sb *singleShardBatcher[T]) currentMetadataCardinality() int {
 	return 1
 }
 
-// multiShardBatcher is used when metadataKeys is not empty.
+// multiShardBatcher is used when metadataKeys or resourceAttributeKeys is not empty.
 type multiShardBatcher[T any] struct {
 	// metadataKeys is the configured list of metadata keys.  When
 	// empty, the `singleton` batcher is used.  When non-empty,
@@ -299,6 +301,14 @@ type multiShardBatcher[T any] struct {
 	// triggers a new batcher, counted in `goroutines`.
 	metadataKeys []string
 
+	// resourceAttributeKeys are the resource attributes used to
+	// further partition the data between shards.
+	resourceAttributeKeys []string
+
+	// groupFunc groups the incoming data by the values of the
+	// resourceAttributeKeys.
+	groupFunc func(T, []string) []resourceGroup[T]
+
 	// metadataLimit is the limiting size of the batchers map.
 	metadataLimit int
 
@@ -335,27 +345,57 @@ func (mb *multiShardBatcher[T]) consume(ctx context.Context, data T) error {
 	}
 	aset := attribute.NewSet(attrs...)
 
-	b, ok := mb.batchers.Load(aset)
+	groups := []resourceGroup[T]{{data: data}}
+	if len(mb.resourceAttributeKeys) != 0 {
+		groups = mb.groupFunc(data, mb.resourceAttributeKeys)
+	}
+
+	// Find the shards of all the groups first, so that no data is
+	// sent when the cardinality limit is reached.
+	shards := make([]*shard[T], len(groups))
+	for i, g := range groups {
+		s, err := mb.getShard(shardKey{metadata: aset, resource: g.attrs}, md)
+		if err != nil {
+			return err
+		}
+		shards[i] = s
+	}
+	for i, s := range shards {
+		s.newItem <- groups[i].data
+	}
+	return nil
+}
+
+// shardKey identifies the shard of a combination of metadata and
+// resource attribute values.
+type shardKey struct {
+	metadata attribute.Set
+	resource attribute.Set
+}
+
+func (mb *multiShardBatcher[T]) getShard(key shardKey, md map[string][]string) (*shard[T], error) {
+	b, ok := mb.batchers.Load(key)
 	if !ok {
 		mb.lock.Lock()
+		defer mb.lock.Unlock()
 		if mb.metadataLimit != 0 && mb.size >= mb.metadataLimit {
-			mb.lock.Unlock()
-			return errTooManyBatchers
+			if b, ok = mb.batchers.Load(key); ok {
+				return b.(*shard[T]), nil
+			}
+			return nil, errTooManyBatchers
 		}
 
-		// aset.ToSlice() returns the sorted, deduplicated,
+		// The attribute sets hold the sorted, deduplicated,
 		// and name-lowercased list of attributes.
 		var loaded bool
-		b, loaded = mb.batchers.LoadOrStore(aset, mb.processor.newShard(md))
+		b, loaded = mb.batchers.LoadOrStore(key, mb.processor.newShard(md))
 		if !loaded {
 			// Start the goroutine only if we added the object to the map, otherwise is already started.
 			b.(*shard[T]).start()
 			mb.size++
 		}
-		mb.lock.Unlock()
 	}
-	b.(*shard[T]).newItem <- data
-	return nil
+	return b.(*shard[T]), nil
 }
 
 func (mb *multiShardBatcher[T]) currentMetadataCardinality() int {
@@ -370,7 +410,11 @@ type tracesBatchProcessor struct {
 
 // newTracesBatchProcessor creates a new batch processor that batches traces by size or with timeout
 func newTracesBatchProcessor(set processor.Settings, next consumer.Traces, cfg *Config) (processor.Traces, error) {
-	bp, err := newBatchProcessor(set, cfg, func() batch[ptrace.Traces] { return newBatchTraces(next) })
+	bp, err := newBatchProcessor(set, cfg, func() batch[ptrace.Traces] {
+		bt := newBatchTraces(next)
+		bt.groupByTraceID = cfg.GroupByTraceID
+		return bt
+	}, groupTracesByResource)
 	if err != nil {
 		return nil, err
 	}
@@ -387,7 +431,7 @@ type metricsBatchProcessor struct {
 
 // newMetricsBatchProcessor creates a new batch processor that batches metrics by size or with timeout
 func newMetricsBatchProcessor(set processor.Settings, next consumer.Metrics, cfg *Config) (processor.Metrics, error) {
-	bp, err := newBatchProcessor(set, cfg, func() batch[pmetric.Metrics] { return newMetricsBatch(next) })
+	bp, err := newBatchProcessor(set, cfg, func() batch[pmetric.Metrics] { return newMetricsBatch(next) }, groupMetricsByResource)
 	if err != nil {
 		return nil, err
 	}
@@ -405,7 +449,7 @@ type logsBatchProcessor struct {
 
 // newLogsBatchProcessor creates a new batch processor that batches logs by size or with timeout
 func newLogsBatchProcessor(set processor.Settings, next consumer.Logs, cfg *Config) (processor.Logs, error) {
-	bp, err := newBatchProcessor(set, cfg, func() batch[plog.Logs] { return newBatchLogs(next) })
+	bp, err := newBatchProcessor(set, cfg, func() batch[plog.Logs] { return newBatchLogs(next) }, groupLogsByResource)
 	if err != nil {
 		return nil, err
 	}
@@ -422,6 +466,8 @@ type batchTraces struct {
 	traceData    ptrace.Traces
 	spanCount    int
 	sizer        ptrace.Sizer
+	// groupByTraceID is set to keep the spans of a trace in the same batch when splitting.
+	groupByTraceID bool
 }
 
 func newBatchTraces(nextConsumer consumer.Traces) *batchTraces {
@@ -451,9 +497,14 @@ func (bt *batchTraces) split(sendBatchMaxSize int) (int, ptrace.Traces) {
 	var td ptrace.Traces
 	var sent int
 	if sendBatchMaxSize > 0 && bt.itemCount() > sendBatchMaxSize {
-		td = splitTraces(sendBatchMaxSize, bt.traceData)
-		bt.spanCount -= sendBatchMaxSize
-		sent = sendBatchMaxSize
+		if bt.groupByTraceID {
+			td = splitTracesByTraceID(sendBatchMaxSize, bt.traceData)
+			sent = td.SpanCount()
+		} else {
+			td = splitTraces(sendBatchMaxSize, bt.traceData)
+			sent = sendBatchMaxSize
+		}
+		bt.spanCount -= sent
 	} else {
 		td = bt.traceData
 		sent = bt.spanCount
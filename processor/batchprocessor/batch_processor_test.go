@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -426,6 +427,47 @@ func TestBatchProcessorSentBySizeWithMaxSize(t *testing.T) {
 	require.NoError(t, tel.Shutdown(context.Background()))
 }
 
+func TestBatchProcessorSentBySizeGroupByTraceID(t *testing.T) {
+	const (
+		sendBatchSize    = 10
+		sendBatchMaxSize = 10
+		traceCount       = 7
+		spansPerTrace    = 4
+	)
+
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = uint32(sendBatchSize)
+	cfg.SendBatchMaxSize = uint32(sendBatchMaxSize)
+	cfg.GroupByTraceID = true
+
+	traces, err := NewFactory().CreateTraces(context.Background(), processortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, traces.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The spans of each trace are interleaved with the spans of the other traces.
+	td := testdata.GenerateTraces(traceCount * spansPerTrace)
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	for i := 0; i < spans.Len(); i++ {
+		spans.At(i).SetTraceID([16]byte{byte(i % traceCount)})
+	}
+	require.NoError(t, traces.ConsumeTraces(context.Background(), td))
+	require.NoError(t, traces.Shutdown(context.Background()))
+
+	require.Equal(t, traceCount*spansPerTrace, sink.SpanCount())
+	batchByTraceID := map[pcommon.TraceID]int{}
+	for i, batch := range sink.AllTraces() {
+		assert.LessOrEqual(t, batch.SpanCount(), sendBatchMaxSize)
+		forEachSpan(batch, func(span ptrace.Span) {
+			if prev, ok := batchByTraceID[span.TraceID()]; ok {
+				assert.Equal(t, prev, i, "trace %v split across batches", span.TraceID())
+			}
+			batchByTraceID[span.TraceID()] = i
+		})
+	}
+	assert.Len(t, batchByTraceID, traceCount)
+}
+
 func TestBatchProcessorSentByTimeout(t *testing.T) {
 	sink := new(consumertest.TracesSink)
 	cfg := createDefaultConfig().(*Config)
@@ -1302,6 +1344,96 @@ func TestBatchProcessorMetadataCardinalityLimit(t *testing.T) {
 	require.NoError(t, traces.Shutdown(context.Background()))
 }
 
+func TestBatchProcessorLogsBatchedByResourceAttributes(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 1000
+	cfg.Timeout = 10 * time.Minute
+	cfg.ResourceAttributeKeys = []string{"tenant.id"}
+	logs, err := NewFactory().CreateLogs(context.Background(), processortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, logs.Start(context.Background(), componenttest.NewNopHost()))
+
+	tenants := []string{"a", "b", "c"}
+	requestCount := 30
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		// Each request holds a resource of each tenant, and one without tenant.
+		ld := plog.NewLogs()
+		for _, tenant := range tenants {
+			rl := testdata.GenerateLogs(2).ResourceLogs().At(0)
+			rl.Resource().Attributes().PutStr("tenant.id", tenant)
+			rl.MoveTo(ld.ResourceLogs().AppendEmpty())
+		}
+		testdata.GenerateLogs(2).ResourceLogs().At(0).MoveTo(ld.ResourceLogs().AppendEmpty())
+		require.NoError(t, logs.ConsumeLogs(context.Background(), ld))
+	}
+
+	require.NoError(t, logs.Shutdown(context.Background()))
+
+	require.Equal(t, requestCount*2*(len(tenants)+1), sink.LogRecordCount())
+	countByTenant := map[string]int{}
+	for _, ld := range sink.AllLogs() {
+		// Each batch holds the logs of a single tenant.
+		tenant := func(i int) string {
+			if v, ok := ld.ResourceLogs().At(i).Resource().Attributes().Get("tenant.id"); ok {
+				return v.AsString()
+			}
+			return "<unset>"
+		}
+		for i := 1; i < ld.ResourceLogs().Len(); i++ {
+			require.Equal(t, tenant(0), tenant(i))
+		}
+		countByTenant[tenant(0)] += ld.LogRecordCount()
+	}
+	for _, tenant := range tenants {
+		assert.Equal(t, requestCount*2, countByTenant[tenant])
+	}
+	assert.Equal(t, requestCount*2, countByTenant["<unset>"])
+}
+
+func TestBatchProcessorDuplicateResourceAttributeKeys(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ResourceAttributeKeys = []string{"tenant.id", "tenant.id"}
+	err := cfg.Validate()
+	require.ErrorContains(t, err, "duplicate")
+	require.ErrorContains(t, err, "tenant.id")
+}
+
+func TestBatchProcessorResourceAttributeCardinalityLimit(t *testing.T) {
+	const cardLimit = 10
+
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetadataKeys = []string{"token"}
+	cfg.ResourceAttributeKeys = []string{"tenant.id"}
+	cfg.MetadataCardinalityLimit = cardLimit
+	traces, err := NewFactory().CreateTraces(context.Background(), processortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, traces.Start(context.Background(), componenttest.NewNopHost()))
+
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{
+			"token": {"single"},
+		}),
+	})
+	for requestNum := 0; requestNum < cardLimit; requestNum++ {
+		td := testdata.GenerateTraces(1)
+		td.ResourceSpans().At(0).Resource().Attributes().PutStr("tenant.id", strconv.Itoa(requestNum))
+		require.NoError(t, traces.ConsumeTraces(ctx, td))
+	}
+
+	td := testdata.GenerateTraces(1)
+	td.ResourceSpans().At(0).Resource().Attributes().PutStr("tenant.id", "limit_exceeded")
+	err = traces.ConsumeTraces(ctx, td)
+
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	require.ErrorContains(t, err, "too many")
+
+	require.NoError(t, traces.Shutdown(context.Background()))
+	assert.Equal(t, cardLimit, sink.SpanCount())
+}
+
 func TestBatchZeroConfig(t *testing.T) {
 	// This is a no-op configuration. No need for a timer, no
 	// minimum, no maximum, just a pass through.
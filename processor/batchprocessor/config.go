@@ -42,8 +42,26 @@ type Config struct {
 
 	// MetadataCardinalityLimit indicates the maximum number of
 	// batcher instances that will be created through a distinct
-	// combination of MetadataKeys.
+	// combination of MetadataKeys and ResourceAttributeKeys.
 	MetadataCardinalityLimit uint32 `mapstructure:"metadata_cardinality_limit"`
+
+	// ResourceAttributeKeys is a list of resource attribute keys that
+	// will be used to form distinct batchers, in addition to MetadataKeys.
+	// When this setting is not empty, one batcher will be used per distinct
+	// combination of values for the listed resource attributes, so that
+	// each batch only holds resources with the same values.
+	//
+	// Unset attributes are treated as distinct from empty values.
+	//
+	// Entries are case-sensitive.  Duplicated entries will trigger
+	// a validation error.
+	ResourceAttributeKeys []string `mapstructure:"resource_attribute_keys"`
+
+	// GroupByTraceID keeps the spans of the same trace, pending when a batch is sent,
+	// in the same batch when it is split to enforce SendBatchMaxSize. A batch may then
+	// contain fewer spans than SendBatchMaxSize, or more when a single trace is larger.
+	// It only applies to traces.
+	GroupByTraceID bool `mapstructure:"group_by_trace_id"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -61,6 +79,13 @@ func (cfg *Config) Validate() error {
 		}
 		uniq[l] = true
 	}
+	uniq = map[string]bool{}
+	for _, k := range cfg.ResourceAttributeKeys {
+		if _, has := uniq[k]; has {
+			return fmt.Errorf("duplicate entry in resource_attribute_keys: %q", k)
+		}
+		uniq[k] = true
+	}
 	if cfg.Timeout < 0 {
 		return errors.New("timeout must be greater or equal to 0")
 	}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchprocessor // import "go.opentelemetry.io/collector/processor/batchprocessor"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// resourceGroup holds the data of the resources with the same values
+// of the resource attribute keys.
+type resourceGroup[T any] struct {
+	attrs attribute.Set
+	data  T
+}
+
+// resourceAttributeSet returns the values of the keys in the resource attributes.
+// Unset attributes are left out of the set.
+func resourceAttributeSet(res pcommon.Resource, keys []string) attribute.Set {
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		if v, ok := res.Attributes().Get(k); ok {
+			attrs = append(attrs, attribute.String(k, v.AsString()))
+		}
+	}
+	return attribute.NewSet(attrs...)
+}
+
+// groupTracesByResource moves the resource spans into groups with the same values of the keys.
+func groupTracesByResource(td ptrace.Traces, keys []string) []resourceGroup[ptrace.Traces] {
+	rss := td.ResourceSpans()
+	if rss.Len() == 1 {
+		return []resourceGroup[ptrace.Traces]{{attrs: resourceAttributeSet(rss.At(0).Resource(), keys), data: td}}
+	}
+	var groups []resourceGroup[ptrace.Traces]
+	index := map[attribute.Distinct]int{}
+	for i := 0; i < rss.Len(); i++ {
+		aset := resourceAttributeSet(rss.At(i).Resource(), keys)
+		idx, ok := index[aset.Equivalent()]
+		if !ok {
+			idx = len(groups)
+			index[aset.Equivalent()] = idx
+			groups = append(groups, resourceGroup[ptrace.Traces]{attrs: aset, data: ptrace.NewTraces()})
+		}
+		rss.At(i).MoveTo(groups[idx].data.ResourceSpans().AppendEmpty())
+	}
+	return groups
+}
+
+// groupMetricsByResource moves the resource metrics into groups with the same values of the keys.
+func groupMetricsByResource(md pmetric.Metrics, keys []string) []resourceGroup[pmetric.Metrics] {
+	rms := md.ResourceMetrics()
+	if rms.Len() == 1 {
+		return []resourceGroup[pmetric.Metrics]{{attrs: resourceAttributeSet(rms.At(0).Resource(), keys), data: md}}
+	}
+	var groups []resourceGroup[pmetric.Metrics]
+	index := map[attribute.Distinct]int{}
+	for i := 0; i < rms.Len(); i++ {
+		aset := resourceAttributeSet(rms.At(i).Resource(), keys)
+		idx, ok := index[aset.Equivalent()]
+		if !ok {
+			idx = len(groups)
+			index[aset.Equivalent()] = idx
+			groups = append(groups, resourceGroup[pmetric.Metrics]{attrs: aset, data: pmetric.NewMetrics()})
+		}
+		rms.At(i).MoveTo(groups[idx].data.ResourceMetrics().AppendEmpty())
+	}
+	return groups
+}
+
+// groupLogsByResource moves the resource logs into groups with the same values of the keys.
+func groupLogsByResource(ld plog.Logs, keys []string) []resourceGroup[plog.Logs] {
+	rls := ld.ResourceLogs()
+	if rls.Len() == 1 {
+		return []resourceGroup[plog.Logs]{{attrs: resourceAttributeSet(rls.At(0).Resource(), keys), data: ld}}
+	}
+	var groups []resourceGroup[plog.Logs]
+	index := map[attribute.Distinct]int{}
+	for i := 0; i < rls.Len(); i++ {
+		aset := resourceAttributeSet(rls.At(i).Resource(), keys)
+		idx, ok := index[aset.Equivalent()]
+		if !ok {
+			idx = len(groups)
+			index[aset.Equivalent()] = idx
+			groups = append(groups, resourceGroup[plog.Logs]{attrs: aset, data: plog.NewLogs()})
+		}
+		rls.At(i).MoveTo(groups[idx].data.ResourceLogs().AppendEmpty())
+	}
+	return groups
+}
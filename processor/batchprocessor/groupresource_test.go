@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestGroupTracesByResourceSingleResource(t *testing.T) {
+	td := testdata.GenerateTraces(2)
+	td.ResourceSpans().At(0).Resource().Attributes().PutInt("tenant.id", 1)
+	groups := groupTracesByResource(td, []string{"tenant.id", "service.name"})
+	require.Len(t, groups, 1)
+	assert.Equal(t, attribute.NewSet(attribute.String("tenant.id", "1")), groups[0].attrs)
+	assert.Equal(t, td, groups[0].data)
+}
+
+func TestGroupTracesByResource(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, tenant := range []string{"a", "b", "a", ""} {
+		rs := testdata.GenerateTraces(1).ResourceSpans().At(0)
+		rs.Resource().Attributes().PutStr("tenant.id", tenant)
+		rs.MoveTo(td.ResourceSpans().AppendEmpty())
+	}
+	testdata.GenerateTraces(1).ResourceSpans().At(0).MoveTo(td.ResourceSpans().AppendEmpty())
+
+	groups := groupTracesByResource(td, []string{"tenant.id"})
+	require.Len(t, groups, 4)
+	assert.Equal(t, attribute.NewSet(attribute.String("tenant.id", "a")), groups[0].attrs)
+	assert.Equal(t, 2, groups[0].data.SpanCount())
+	assert.Equal(t, attribute.NewSet(attribute.String("tenant.id", "b")), groups[1].attrs)
+	assert.Equal(t, 1, groups[1].data.SpanCount())
+	// Empty values are distinct from unset attributes.
+	assert.Equal(t, attribute.NewSet(attribute.String("tenant.id", "")), groups[2].attrs)
+	assert.Equal(t, 1, groups[2].data.SpanCount())
+	assert.Equal(t, attribute.NewSet(), groups[3].attrs)
+	assert.Equal(t, 1, groups[3].data.SpanCount())
+}
+
+func TestGroupMetricsByResource(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, tenant := range []string{"a", "b", "a"} {
+		rm := testdata.GenerateMetrics(1).ResourceMetrics().At(0)
+		rm.Resource().Attributes().PutStr("tenant.id", tenant)
+		rm.MoveTo(md.ResourceMetrics().AppendEmpty())
+	}
+
+	groups := groupMetricsByResource(md, []string{"tenant.id"})
+	require.Len(t, groups, 2)
+	assert.Equal(t, 2, groups[0].data.ResourceMetrics().Len())
+	assert.Equal(t, 1, groups[1].data.ResourceMetrics().Len())
+}
+
+func TestGroupLogsByResource(t *testing.T) {
+	ld := plog.NewLogs()
+	for _, tenant := range []string{"a", "b", "a"} {
+		rl := testdata.GenerateLogs(1).ResourceLogs().At(0)
+		rl.Resource().Attributes().PutStr("tenant.id", tenant)
+		rl.MoveTo(ld.ResourceLogs().AppendEmpty())
+	}
+
+	groups := groupLogsByResource(ld, []string{"tenant.id"})
+	require.Len(t, groups, 2)
+	assert.Equal(t, 2, groups[0].data.LogRecordCount())
+	assert.Equal(t, 1, groups[1].data.LogRecordCount())
+}
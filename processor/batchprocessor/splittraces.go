@@ -4,6 +4,7 @@
 package batchprocessor // import "go.opentelemetry.io/collector/processor/batchprocessor"
 
 import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -64,6 +65,80 @@ func splitTraces(size int, src ptrace.Traces) ptrace.Traces {
 	return dest
 }
 
+// splitTracesByTraceID removes the spans of whole traces from the input trace, in the order
+// the traces first appear, and returns a new trace of at most the specified size.
+// The first trace is returned entirely even if it alone is larger than the specified size.
+func splitTracesByTraceID(size int, src ptrace.Traces) ptrace.Traces {
+	if src.SpanCount() <= size {
+		return src
+	}
+
+	traceSC := map[pcommon.TraceID]int{}
+	var traceIDs []pcommon.TraceID
+	forEachSpan(src, func(span ptrace.Span) {
+		if _, ok := traceSC[span.TraceID()]; !ok {
+			traceIDs = append(traceIDs, span.TraceID())
+		}
+		traceSC[span.TraceID()]++
+	})
+
+	selected := map[pcommon.TraceID]struct{}{}
+	totalSelectedSpans := 0
+	for _, traceID := range traceIDs {
+		if totalSelectedSpans > 0 && totalSelectedSpans+traceSC[traceID] > size {
+			break
+		}
+		selected[traceID] = struct{}{}
+		totalSelectedSpans += traceSC[traceID]
+	}
+
+	dest := ptrace.NewTraces()
+	src.ResourceSpans().RemoveIf(func(srcRs ptrace.ResourceSpans) bool {
+		var destRs ptrace.ResourceSpans
+		destRsFound := false
+		srcRs.ScopeSpans().RemoveIf(func(srcIls ptrace.ScopeSpans) bool {
+			var destIls ptrace.ScopeSpans
+			destIlsFound := false
+			srcIls.Spans().RemoveIf(func(srcSpan ptrace.Span) bool {
+				if _, ok := selected[srcSpan.TraceID()]; !ok {
+					return false
+				}
+				if !destRsFound {
+					destRs = dest.ResourceSpans().AppendEmpty()
+					srcRs.Resource().CopyTo(destRs.Resource())
+					destRs.SetSchemaUrl(srcRs.SchemaUrl())
+					destRsFound = true
+				}
+				if !destIlsFound {
+					destIls = destRs.ScopeSpans().AppendEmpty()
+					srcIls.Scope().CopyTo(destIls.Scope())
+					destIls.SetSchemaUrl(srcIls.SchemaUrl())
+					destIlsFound = true
+				}
+				srcSpan.MoveTo(destIls.Spans().AppendEmpty())
+				return true
+			})
+			return srcIls.Spans().Len() == 0
+		})
+		return srcRs.ScopeSpans().Len() == 0
+	})
+
+	return dest
+}
+
+// forEachSpan calls f for each span of the ptrace.Traces.
+func forEachSpan(td ptrace.Traces, f func(ptrace.Span)) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				f(spans.At(k))
+			}
+		}
+	}
+}
+
 // resourceSC calculates the total number of spans in the ptrace.ResourceSpans.
 func resourceSC(rs ptrace.ResourceSpans) (count int) {
 	for k := 0; k < rs.ScopeSpans().Len(); k++ {
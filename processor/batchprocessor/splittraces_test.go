@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
@@ -145,3 +146,64 @@ func TestSplitTracesMultipleILS(t *testing.T) {
 	assert.Equal(t, "test-span-0-0", split.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
 	assert.Equal(t, "test-span-0-4", split.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(4).Name())
 }
+
+func TestSplitTracesByTraceID(t *testing.T) {
+	td := testdata.GenerateTraces(10)
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	// Traces 0, 1 and 2 have respectively 4, 3 and 3 spans.
+	for i := 0; i < spans.Len(); i++ {
+		spans.At(i).SetName(getTestSpanName(0, i))
+		spans.At(i).SetTraceID([16]byte{byte(i % 3)})
+	}
+
+	split := splitTracesByTraceID(5, td)
+	assert.Equal(t, 4, split.SpanCount())
+	assert.Equal(t, 6, td.SpanCount())
+	forEachSpan(split, func(span ptrace.Span) {
+		assert.Equal(t, pcommon.TraceID([16]byte{0}), span.TraceID())
+	})
+	assert.Equal(t, td.ResourceSpans().At(0).Resource(), split.ResourceSpans().At(0).Resource())
+	assert.Equal(t, "test-span-0-0", split.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	assert.Equal(t, "test-span-0-9", split.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(3).Name())
+
+	split = splitTracesByTraceID(5, td)
+	assert.Equal(t, 3, split.SpanCount())
+	assert.Equal(t, 3, td.SpanCount())
+	assert.Equal(t, "test-span-0-1", split.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+
+	split = splitTracesByTraceID(5, td)
+	assert.Equal(t, td, split)
+}
+
+func TestSplitTracesByTraceIDLargerTrace(t *testing.T) {
+	td := testdata.GenerateTraces(10)
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	for i := 0; i < spans.Len(); i++ {
+		spans.At(i).SetTraceID([16]byte{byte(i / 8)})
+	}
+
+	// The first trace is larger than the split size, it is not split.
+	split := splitTracesByTraceID(5, td)
+	assert.Equal(t, 8, split.SpanCount())
+	assert.Equal(t, 2, td.SpanCount())
+}
+
+func TestSplitTracesByTraceIDMultipleResourceSpans(t *testing.T) {
+	td := testdata.GenerateTraces(4)
+	td.ResourceSpans().At(0).CopyTo(td.ResourceSpans().AppendEmpty())
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		spans := td.ResourceSpans().At(i).ScopeSpans().At(0).Spans()
+		for j := 0; j < spans.Len(); j++ {
+			spans.At(j).SetTraceID([16]byte{byte(j % 2)})
+		}
+	}
+
+	split := splitTracesByTraceID(5, td)
+	assert.Equal(t, 4, split.SpanCount())
+	assert.Equal(t, 2, split.ResourceSpans().Len())
+	assert.Equal(t, 4, td.SpanCount())
+	assert.Equal(t, 2, td.ResourceSpans().Len())
+	forEachSpan(split, func(span ptrace.Span) {
+		assert.Equal(t, pcommon.TraceID([16]byte{0}), span.TraceID())
+	})
+}
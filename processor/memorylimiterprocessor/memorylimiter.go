@@ -17,7 +17,9 @@ import (
 
 type memoryLimiterProcessor struct {
 	memlimiter *memorylimiter.MemoryLimiter
-	obsrep     *obsReport
+	// budget is the name of the budget the data going through the processor is attributed to.
+	budget string
+	obsrep *obsReport
 }
 
 // newMemoryLimiter returns a new memorylimiter processor.
@@ -33,6 +35,7 @@ func newMemoryLimiterProcessor(set processor.Settings, cfg *Config) (*memoryLimi
 
 	p := &memoryLimiterProcessor{
 		memlimiter: ml,
+		budget:     cfg.Budget,
 		obsrep:     obsrep,
 	}
 
@@ -49,7 +52,7 @@ func (p *memoryLimiterProcessor) shutdown(ctx context.Context) error {
 
 func (p *memoryLimiterProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
 	numSpans := td.SpanCount()
-	if p.memlimiter.MustRefuse() {
+	if p.memlimiter.MustRefuseBudget(p.budget) {
 		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
 		// 	it is necessary to check the pipeline to see if this is directly connected
 		// 	to a receiver (ie.: a receiver is on the call stack). For now it
@@ -67,7 +70,7 @@ func (p *memoryLimiterProcessor) processTraces(ctx context.Context, td ptrace.Tr
 
 func (p *memoryLimiterProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
 	numDataPoints := md.DataPointCount()
-	if p.memlimiter.MustRefuse() {
+	if p.memlimiter.MustRefuseBudget(p.budget) {
 		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
 		// 	it is necessary to check the pipeline to see if this is directly connected
 		// 	to a receiver (ie.: a receiver is on the call stack). For now it
@@ -85,7 +88,7 @@ func (p *memoryLimiterProcessor) processMetrics(ctx context.Context, md pmetric.
 
 func (p *memoryLimiterProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
 	numRecords := ld.LogRecordCount()
-	if p.memlimiter.MustRefuse() {
+	if p.memlimiter.MustRefuseBudget(p.budget) {
 		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
 		// 	it is necessary to check the pipeline to see if this is directly connected
 		// 	to a receiver (ie.: a receiver is on the call stack). For now it
@@ -467,3 +467,48 @@ func (h *host) GetExtensions() map[component.ID]component.Component {
 func totalMemory() (uint64, error) {
 	return uint64(2048), nil
 }
+
+func TestBudgetMemoryPressureResponse(t *testing.T) {
+	ctx := context.Background()
+	newConfig := func(budget string) *Config {
+		return &Config{
+			CheckInterval:       time.Second,
+			MemoryLimitMiB:      1000,
+			MemorySpikeLimitMiB: 200,
+			Budgets: map[string]memorylimiter.BudgetConfig{
+				"logs": {LimitPercentage: 50},
+			},
+			Budget: budget,
+		}
+	}
+	logsCfg, defaultCfg := newConfig("logs"), newConfig("")
+	// Above the logs budget of 400MiB, below the soft limit of 800MiB.
+	memorylimiter.ReadMemStatsFn = func(ms *runtime.MemStats) {
+		ms.Alloc = 500 * 1024 * 1024
+	}
+	t.Cleanup(func() {
+		memorylimiter.ReadMemStatsFn = runtime.ReadMemStats
+	})
+
+	f := &factory{memoryLimiters: map[component.Config]*memoryLimiterProcessor{}}
+	set := processortest.NewNopSettings()
+	lp, err := f.createLogs(ctx, set, logsCfg, consumertest.NewNop())
+	require.NoError(t, err)
+	dlp, err := f.createLogs(ctx, set, defaultCfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(ctx, &host{}))
+	require.NoError(t, dlp.Start(ctx, &host{}))
+
+	for _, cfg := range []*Config{logsCfg, defaultCfg} {
+		ml, err := f.getMemoryLimiter(set, cfg)
+		require.NoError(t, err)
+		ml.memlimiter.CheckMemLimits()
+	}
+
+	// Only the processor attributing its data to the logs budget refuses data.
+	assert.Equal(t, memorylimiter.ErrDataRefused, lp.ConsumeLogs(ctx, plog.NewLogs()))
+	assert.NoError(t, dlp.ConsumeLogs(ctx, plog.NewLogs()))
+
+	require.NoError(t, lp.Shutdown(ctx))
+	require.NoError(t, dlp.Shutdown(ctx))
+}
@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confighttp/xconfighttp"
 	"go.opentelemetry.io/collector/confmap"
 )
 
@@ -32,6 +33,9 @@ type HTTPConfig struct {
 
 	// The URL path to receive logs on. If omitted "/v1/logs" will be used.
 	LogsURLPath string `mapstructure:"logs_url_path,omitempty"`
+
+	// HTTP3 configures the reception of HTTP/3 requests over QUIC, on the UDP port of the endpoint.
+	HTTP3 *xconfighttp.HTTP3Config `mapstructure:"http3"`
 }
 
 // Protocols is the configuration for the supported protocols.
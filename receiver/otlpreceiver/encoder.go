@@ -17,13 +17,15 @@ import (
 )
 
 const (
-	pbContentType   = "application/x-protobuf"
-	jsonContentType = "application/json"
+	pbContentType     = "application/x-protobuf"
+	jsonContentType   = "application/json"
+	ndjsonContentType = "application/x-ndjson"
 )
 
 var (
 	pbEncoder       = &protoEncoder{}
 	jsEncoder       = &jsonEncoder{}
+	ndjsEncoder     = &ndjsonEncoder{}
 	jsonPbMarshaler = &jsonpb.Marshaler{}
 )
 
@@ -144,3 +146,9 @@ func (jsonEncoder) marshalStatus(resp *spb.Status) ([]byte, error) {
 func (jsonEncoder) contentType() string {
 	return jsonContentType
 }
+
+// ndjsonEncoder decodes each line of a newline-delimited JSON body as a JSON request,
+// and encodes the responses as JSON.
+type ndjsonEncoder struct {
+	jsonEncoder
+}
@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Names of the OTLP gRPC services, reported individually by the health service.
+const (
+	tracesServiceName   = "opentelemetry.proto.collector.trace.v1.TraceService"
+	metricsServiceName  = "opentelemetry.proto.collector.metrics.v1.MetricsService"
+	logsServiceName     = "opentelemetry.proto.collector.logs.v1.LogsService"
+	profilesServiceName = "opentelemetry.proto.collector.profiles.v1development.ProfilesService"
+)
+
+const (
+	// refusedHoldTime is how long a service is reported as NOT_SERVING after its pipeline
+	// refused data because of high memory usage. Clients backing off would otherwise never
+	// send the data telling the receiver that the pipeline accepts data again.
+	refusedHoldTime = 5 * time.Second
+	// memoryLimiterPollInterval is how often the memory limiter extension of the gRPC server
+	// is consulted, since the requests it refuses never reach the pipelines.
+	memoryLimiterPollInterval = time.Second
+)
+
+// memoryLimiter is implemented by the memory limiter extension.
+type memoryLimiter interface {
+	// MustRefuseBudget returns if the data attributed to the named budget must be refused
+	// because of high memory usage.
+	MustRefuseBudget(name string) bool
+}
+
+// grpcHealth implements the gRPC health checking protocol for the OTLP services.
+// The overall status, reported for the empty service name, is SERVING once the receiver
+// is started and as long as no pipeline, nor the memory limiter extension of the gRPC
+// server, refuses data because of high memory usage.
+type grpcHealth struct {
+	server   *health.Server
+	holdTime time.Duration
+	// watchCtx is canceled on shutdown to end the Watch streams, which would otherwise
+	// prevent the gRPC server from stopping gracefully.
+	watchCtx    context.Context
+	cancelWatch context.CancelFunc
+
+	mu       sync.Mutex
+	host     component.Host
+	services []string
+	started  bool
+	stopped  bool
+	// refused holds the timer making a service SERVING again, for each service whose
+	// pipeline refused data.
+	refused map[string]*time.Timer
+}
+
+var _ healthpb.HealthServer = (*grpcHealth)(nil)
+
+func newGRPCHealth() *grpcHealth {
+	h := &grpcHealth{
+		server:   health.NewServer(),
+		holdTime: refusedHoldTime,
+		refused:  make(map[string]*time.Timer),
+	}
+	h.watchCtx, h.cancelWatch = context.WithCancel(context.Background())
+	h.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return h
+}
+
+func (h *grpcHealth) register(server *grpc.Server) {
+	healthpb.RegisterHealthServer(server, h)
+}
+
+// Check implements healthpb.HealthServer.
+func (h *grpcHealth) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	return h.server.Check(ctx, req)
+}
+
+// Watch implements healthpb.HealthServer.
+func (h *grpcHealth) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	stop := context.AfterFunc(h.watchCtx, cancel)
+	defer stop()
+	return h.server.Watch(req, &watchStream{Health_WatchServer: stream, ctx: ctx})
+}
+
+type watchStream struct {
+	healthpb.Health_WatchServer
+	ctx context.Context
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+// addService adds a service whose status is reported by the health service.
+func (h *grpcHealth) addService(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.services = append(h.services, name)
+	h.server.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// start makes all services SERVING, and makes them NOT_SERVING while limiter, if not nil,
+// refuses the data of budget.
+func (h *grpcHealth) start(host component.Host, limiter memoryLimiter, budget string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.host = host
+	h.started = true
+	for _, name := range h.services {
+		h.server.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	h.server.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	if limiter != nil {
+		go h.pollMemoryLimiter(limiter, budget, memoryLimiterPollInterval)
+	}
+}
+
+// pollMemoryLimiter refuses all services while limiter refuses the data of budget, until shutdown.
+func (h *grpcHealth) pollMemoryLimiter(limiter memoryLimiter, budget string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.watchCtx.Done():
+			return
+		case <-ticker.C:
+			if !limiter.MustRefuseBudget(budget) {
+				continue
+			}
+			h.mu.Lock()
+			services := append([]string(nil), h.services...)
+			h.mu.Unlock()
+			for _, service := range services {
+				h.refuse(service, consumererror.ErrDataRefused)
+			}
+		}
+	}
+}
+
+// shutdown makes all services NOT_SERVING, ignoring any later update, and ends
+// the Watch streams.
+func (h *grpcHealth) shutdown() {
+	h.mu.Lock()
+	h.stopped = true
+	for name, timer := range h.refused {
+		timer.Stop()
+		delete(h.refused, name)
+	}
+	h.mu.Unlock()
+	h.server.Shutdown()
+	h.cancelWatch()
+}
+
+// fatal makes all services NOT_SERVING after the receiver reported a fatal error.
+func (h *grpcHealth) fatal() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, name := range h.services {
+		h.server.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	h.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	h.started = false
+}
+
+// consumed updates the status of service after its pipeline consumed data and returned err.
+func (h *grpcHealth) consumed(service string, err error) {
+	switch {
+	case errors.Is(err, consumererror.ErrDataRefused):
+		h.refuse(service, err)
+	case err == nil:
+		h.mu.Lock()
+		_, refused := h.refused[service]
+		h.mu.Unlock()
+		if refused {
+			h.recover(service)
+		}
+	}
+}
+
+func (h *grpcHealth) refuse(service string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.started || h.stopped {
+		return
+	}
+	if timer, ok := h.refused[service]; ok {
+		timer.Reset(h.holdTime)
+		return
+	}
+	h.refused[service] = time.AfterFunc(h.holdTime, func() { h.recover(service) })
+	h.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	if len(h.refused) == 1 {
+		h.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		componentstatus.ReportStatus(h.host, componentstatus.NewRecoverableErrorEvent(err))
+	}
+}
+
+func (h *grpcHealth) recover(service string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timer, ok := h.refused[service]
+	if !ok || !h.started || h.stopped {
+		return
+	}
+	timer.Stop()
+	delete(h.refused, service)
+	h.server.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	if len(h.refused) == 0 {
+		h.server.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+		componentstatus.ReportStatus(h.host, componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+}
+
+func (h *grpcHealth) traces(next consumer.Traces) consumer.Traces {
+	h.addService(tracesServiceName)
+	c, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		err := next.ConsumeTraces(ctx, td)
+		h.consumed(tracesServiceName, err)
+		return err
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return c
+}
+
+func (h *grpcHealth) metrics(next consumer.Metrics) consumer.Metrics {
+	h.addService(metricsServiceName)
+	c, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		err := next.ConsumeMetrics(ctx, md)
+		h.consumed(metricsServiceName, err)
+		return err
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return c
+}
+
+func (h *grpcHealth) logs(next consumer.Logs) consumer.Logs {
+	h.addService(logsServiceName)
+	c, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		err := next.ConsumeLogs(ctx, ld)
+		h.consumed(logsServiceName, err)
+		return err
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return c
+}
+
+func (h *grpcHealth) profiles(next xconsumer.Profiles) xconsumer.Profiles {
+	h.addService(profilesServiceName)
+	c, _ := xconsumer.NewProfiles(func(ctx context.Context, pd pprofile.Profiles) error {
+		err := next.ConsumeProfiles(ctx, pd)
+		h.consumed(profilesServiceName, err)
+		return err
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return c
+}
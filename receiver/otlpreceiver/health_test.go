@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+type statusHost struct {
+	component.Host
+	mu       sync.Mutex
+	statuses []componentstatus.Status
+}
+
+func (h *statusHost) Report(e *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.statuses = append(h.statuses, e.Status())
+}
+
+func (h *statusHost) reported() []componentstatus.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]componentstatus.Status(nil), h.statuses...)
+}
+
+func checkServing(t *testing.T, client healthpb.HealthClient, service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return resp.GetStatus()
+}
+
+func TestGRPCHealth(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := newErrOrSinkConsumer()
+	recv := newGRPCReceiver(t, componenttest.NewNopTelemetrySettings(), addr, sink)
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, recv.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, cc.Close()) })
+	client := healthpb.NewHealthClient(cc)
+
+	for _, service := range []string{"", tracesServiceName, metricsServiceName, logsServiceName, profilesServiceName} {
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkServing(t, client, service))
+	}
+
+	req := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(1))
+	sink.SetConsumeError(consumererror.ErrDataRefused)
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), req)
+	require.Error(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkServing(t, client, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkServing(t, client, tracesServiceName))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkServing(t, client, metricsServiceName))
+
+	sink.SetConsumeError(nil)
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkServing(t, client, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkServing(t, client, tracesServiceName))
+
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, host.reported())
+}
+
+func TestGRPCHealthRefusedHoldTime(t *testing.T) {
+	h := newGRPCHealth()
+	h.holdTime = 10 * time.Millisecond
+	h.addService(logsServiceName)
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	h.start(host, nil, "")
+	t.Cleanup(h.shutdown)
+
+	h.consumed(logsServiceName, consumererror.ErrDataRefused)
+	resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: logsServiceName})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+
+	assert.Eventually(t, func() bool {
+		resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		return err == nil && resp.GetStatus() == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, host.reported())
+}
+
+type refusingLimiter struct {
+	refuse atomic.Bool
+}
+
+func (l *refusingLimiter) MustRefuseBudget(name string) bool {
+	return l.refuse.Load() && name == "traces"
+}
+
+func TestGRPCHealthMemoryLimiter(t *testing.T) {
+	h := newGRPCHealth()
+	h.holdTime = 10 * time.Millisecond
+	h.addService(tracesServiceName)
+	h.start(&statusHost{Host: componenttest.NewNopHost()}, nil, "")
+	t.Cleanup(h.shutdown)
+
+	limiter := &refusingLimiter{}
+	limiter.refuse.Store(true)
+	go h.pollMemoryLimiter(limiter, "traces", time.Millisecond)
+	servingStatus := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: tracesServiceName})
+		require.NoError(t, err)
+		return resp.GetStatus()
+	}
+	assert.Eventually(t, func() bool {
+		return servingStatus() == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, time.Millisecond)
+
+	limiter.refuse.Store(false)
+	assert.Eventually(t, func() bool {
+		return servingStatus() == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, time.Millisecond)
+}
+
+func TestGRPCHealthShutdown(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	recv := newGRPCReceiver(t, componenttest.NewNopTelemetrySettings(), addr, newErrOrSinkConsumer())
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, cc.Close()) })
+	watch, err := healthpb.NewHealthClient(cc).Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	resp, err := watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	// Open Watch streams must not prevent the gRPC server from stopping.
+	require.NoError(t, recv.Shutdown(context.Background()))
+	for {
+		resp, err = watch.Recv()
+		if err != nil {
+			break
+		}
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+	}
+}
@@ -4,6 +4,7 @@
 package errors // import "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 
 import (
+	"errors"
 	"net/http"
 
 	"google.golang.org/grpc/codes"
@@ -56,3 +57,19 @@ func GetHTTPStatusCodeFromStatus(s *status.Status) int {
 		return http.StatusInternalServerError
 	}
 }
+
+// GetRejectedItems returns the number of items rejected by a permanent error carrying
+// the rejected data, such as consumererror.Traces, when only part of the received items
+// were rejected. These are reported to the client as a partial success, as the rejected
+// items must not be retried.
+func GetRejectedItems[E error](err error, received int, count func(E) int) (int, bool) {
+	var e E
+	if !consumererror.IsPermanent(err) || !errors.As(err, &e) {
+		return 0, false
+	}
+	rejected := count(e)
+	if rejected <= 0 || rejected >= received {
+		return 0, false
+	}
+	return rejected, true
+}
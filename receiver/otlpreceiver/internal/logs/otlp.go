@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
@@ -48,6 +49,13 @@ func (r *Receiver) Export(ctx context.Context, req plogotlp.ExportRequest) (plog
 	// NonPermanent errors will be converted to codes.Unavailable (equivalent to HTTP 503)
 	// Permanent errors will be converted to codes.InvalidArgument (equivalent to HTTP 400)
 	if err != nil {
+		// Permanent errors carrying a part of the data are reported as a partial success.
+		if rejected, ok := errors.GetRejectedItems(err, numSpans, func(e consumererror.Logs) int { return e.Data().LogRecordCount() }); ok {
+			resp := plogotlp.NewExportResponse()
+			resp.PartialSuccess().SetRejectedLogRecords(int64(rejected))
+			resp.PartialSuccess().SetErrorMessage(err.Error())
+			return resp, nil
+		}
 		return plogotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
 
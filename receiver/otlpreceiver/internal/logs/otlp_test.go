@@ -72,6 +72,27 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, plogotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartiallyRejected(t *testing.T) {
+	td := testdata.GenerateLogs(4)
+	req := plogotlp.NewExportRequestFromLogs(td)
+
+	rejected := testdata.GenerateLogs(1)
+	logClient := makeLogsServiceClient(t, consumertest.NewErr(consumererror.NewPermanent(consumererror.NewLogs(errors.New("my error"), rejected))))
+	resp, err := logClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(rejected.LogRecordCount()), resp.PartialSuccess().RejectedLogRecords())
+	assert.Equal(t, "Permanent error: my error", resp.PartialSuccess().ErrorMessage())
+}
+
+func TestExport_AllRejected(t *testing.T) {
+	td := testdata.GenerateLogs(1)
+	req := plogotlp.NewExportRequestFromLogs(td)
+
+	logClient := makeLogsServiceClient(t, consumertest.NewErr(consumererror.NewPermanent(consumererror.NewLogs(errors.New("my error"), td))))
+	_, err := logClient.Export(context.Background(), req)
+	require.EqualError(t, err, "rpc error: code = Internal desc = Permanent error: my error")
+}
+
 func makeLogsServiceClient(t *testing.T, lc consumer.Logs) plogotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, lc)
 	cc, err := grpc.NewClient(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
@@ -48,6 +49,13 @@ func (r *Receiver) Export(ctx context.Context, req pmetricotlp.ExportRequest) (p
 	// NonPermanent errors will be converted to codes.Unavailable (equivalent to HTTP 503)
 	// Permanent errors will be converted to codes.InvalidArgument (equivalent to HTTP 400)
 	if err != nil {
+		// Permanent errors carrying a part of the data are reported as a partial success.
+		if rejected, ok := errors.GetRejectedItems(err, dataPointCount, func(e consumererror.Metrics) int { return e.Data().DataPointCount() }); ok {
+			resp := pmetricotlp.NewExportResponse()
+			resp.PartialSuccess().SetRejectedDataPoints(int64(rejected))
+			resp.PartialSuccess().SetErrorMessage(err.Error())
+			return resp, nil
+		}
 		return pmetricotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
 
@@ -72,6 +72,27 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, pmetricotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartiallyRejected(t *testing.T) {
+	td := testdata.GenerateMetrics(4)
+	req := pmetricotlp.NewExportRequestFromMetrics(td)
+
+	rejected := testdata.GenerateMetrics(1)
+	metricsClient := makeMetricsServiceClient(t, consumertest.NewErr(consumererror.NewPermanent(consumererror.NewMetrics(errors.New("my error"), rejected))))
+	resp, err := metricsClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(rejected.DataPointCount()), resp.PartialSuccess().RejectedDataPoints())
+	assert.Equal(t, "Permanent error: my error", resp.PartialSuccess().ErrorMessage())
+}
+
+func TestExport_AllRejected(t *testing.T) {
+	td := testdata.GenerateMetrics(1)
+	req := pmetricotlp.NewExportRequestFromMetrics(td)
+
+	metricsClient := makeMetricsServiceClient(t, consumertest.NewErr(consumererror.NewPermanent(consumererror.NewMetrics(errors.New("my error"), td))))
+	_, err := metricsClient.Export(context.Background(), req)
+	require.EqualError(t, err, "rpc error: code = Internal desc = Permanent error: my error")
+}
+
 func makeMetricsServiceClient(t *testing.T, mc consumer.Metrics) pmetricotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, mc)
 
@@ -6,6 +6,7 @@ package profiles // import "go.opentelemetry.io/collector/receiver/otlpreceiver/
 import (
 	"context"
 
+	"go.opentelemetry.io/collector/consumer/consumererror/xconsumererror"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
@@ -41,6 +42,13 @@ func (r *Receiver) Export(ctx context.Context, req pprofileotlp.ExportRequest) (
 	// NonPermanent errors will be converted to codes.Unavailable (equivalent to HTTP 503)
 	// Permanent errors will be converted to codes.InvalidArgument (equivalent to HTTP 400)
 	if err != nil {
+		// Permanent errors carrying a part of the data are reported as a partial success.
+		if rejected, ok := errors.GetRejectedItems(err, numProfiles, func(e xconsumererror.Profiles) int { return e.Data().SampleCount() }); ok {
+			resp := pprofileotlp.NewExportResponse()
+			resp.PartialSuccess().SetRejectedProfiles(int64(rejected))
+			resp.PartialSuccess().SetErrorMessage(err.Error())
+			return resp, nil
+		}
 		return pprofileotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
 
@@ -17,6 +17,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumererror/xconsumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
@@ -67,6 +68,27 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, pprofileotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartiallyRejected(t *testing.T) {
+	td := testdata.GenerateProfiles(4)
+	req := pprofileotlp.NewExportRequestFromProfiles(td)
+
+	rejected := testdata.GenerateProfiles(1)
+	profileClient := makeProfileServiceClient(t, consumertest.NewErr(consumererror.NewPermanent(xconsumererror.NewProfiles(errors.New("my error"), rejected))))
+	resp, err := profileClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(rejected.SampleCount()), resp.PartialSuccess().RejectedProfiles())
+	assert.Equal(t, "Permanent error: my error", resp.PartialSuccess().ErrorMessage())
+}
+
+func TestExport_AllRejected(t *testing.T) {
+	td := testdata.GenerateProfiles(1)
+	req := pprofileotlp.NewExportRequestFromProfiles(td)
+
+	profileClient := makeProfileServiceClient(t, consumertest.NewErr(consumererror.NewPermanent(xconsumererror.NewProfiles(errors.New("my error"), td))))
+	_, err := profileClient.Export(context.Background(), req)
+	require.EqualError(t, err, "rpc error: code = Internal desc = Permanent error: my error")
+}
+
 func makeProfileServiceClient(t *testing.T, tc xconsumer.Profiles) pprofileotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, tc)
 	cc, err := grpc.NewClient(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
@@ -49,6 +50,13 @@ func (r *Receiver) Export(ctx context.Context, req ptraceotlp.ExportRequest) (pt
 	// NonPermanent errors will be converted to codes.Unavailable (equivalent to HTTP 503)
 	// Permanent errors will be converted to codes.InvalidArgument (equivalent to HTTP 400)
 	if err != nil {
+		// Permanent errors carrying a part of the data are reported as a partial success.
+		if rejected, ok := errors.GetRejectedItems(err, numSpans, func(e consumererror.Traces) int { return e.Data().SpanCount() }); ok {
+			resp := ptraceotlp.NewExportResponse()
+			resp.PartialSuccess().SetRejectedSpans(int64(rejected))
+			resp.PartialSuccess().SetErrorMessage(err.Error())
+			return resp, nil
+		}
 		return ptraceotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
 
@@ -70,6 +70,27 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, ptraceotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartiallyRejected(t *testing.T) {
+	td := testdata.GenerateTraces(4)
+	req := ptraceotlp.NewExportRequestFromTraces(td)
+
+	rejected := testdata.GenerateTraces(1)
+	traceClient := makeTraceServiceClient(t, consumertest.NewErr(consumererror.NewPermanent(consumererror.NewTraces(errors.New("my error"), rejected))))
+	resp, err := traceClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(rejected.SpanCount()), resp.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "Permanent error: my error", resp.PartialSuccess().ErrorMessage())
+}
+
+func TestExport_AllRejected(t *testing.T) {
+	td := testdata.GenerateTraces(1)
+	req := ptraceotlp.NewExportRequestFromTraces(td)
+
+	traceClient := makeTraceServiceClient(t, consumertest.NewErr(consumererror.NewPermanent(consumererror.NewTraces(errors.New("my error"), td))))
+	_, err := traceClient.Export(context.Background(), req)
+	require.EqualError(t, err, "rpc error: code = Internal desc = Permanent error: my error")
+}
+
 func makeTraceServiceClient(t *testing.T, tc consumer.Traces) ptraceotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, tc)
 	cc, err := grpc.NewClient(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
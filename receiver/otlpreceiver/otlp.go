@@ -10,12 +10,15 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confighttp/xconfighttp"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
@@ -35,6 +38,12 @@ type otlpReceiver struct {
 	cfg        *Config
 	serverGRPC *grpc.Server
 	serverHTTP *http.Server
+	// serverHTTP3 serves the HTTP/3 requests received by listenerHTTP3, nil if HTTP/3
+	// is not enabled.
+	serverHTTP3   *http3.Server
+	listenerHTTP3 *quic.EarlyListener
+	// health is the gRPC health service, nil if gRPC is not enabled.
+	health *grpcHealth
 
 	nextTraces   consumer.Traces
 	nextMetrics  consumer.Metrics
@@ -60,6 +69,9 @@ func newOtlpReceiver(cfg *Config, set *receiver.Settings) (*otlpReceiver, error)
 		nextProfiles: nil,
 		settings:     set,
 	}
+	if cfg.GRPC != nil {
+		r.health = newGRPCHealth()
+	}
 
 	var err error
 	r.obsrepGRPC, err = receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
@@ -109,6 +121,8 @@ func (r *otlpReceiver) startGRPCServer(host component.Host) error {
 		pprofileotlp.RegisterGRPCServer(r.serverGRPC, profiles.New(r.nextProfiles))
 	}
 
+	r.health.register(r.serverGRPC)
+
 	r.settings.Logger.Info("Starting GRPC server", zap.String("endpoint", r.cfg.GRPC.NetAddr.Endpoint))
 	var gln net.Listener
 	if gln, err = r.cfg.GRPC.NetAddr.Listen(context.Background()); err != nil {
@@ -120,6 +134,7 @@ func (r *otlpReceiver) startGRPCServer(host component.Host) error {
 		defer r.shutdownWG.Done()
 
 		if errGrpc := r.serverGRPC.Serve(gln); errGrpc != nil && !errors.Is(errGrpc, grpc.ErrServerStopped) {
+			r.health.fatal()
 			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errGrpc))
 		}
 	}()
@@ -180,6 +195,24 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
 		}
 	}()
+
+	if r.cfg.HTTP.HTTP3 == nil || !r.cfg.HTTP.HTTP3.Enabled {
+		return nil
+	}
+	r.settings.Logger.Info("Starting HTTP/3 server", zap.String("endpoint", r.cfg.HTTP.ServerConfig.Endpoint))
+	if r.listenerHTTP3, err = r.cfg.HTTP.HTTP3.ToQUICListener(ctx, r.cfg.HTTP.ServerConfig); err != nil {
+		return err
+	}
+	r.serverHTTP3 = xconfighttp.NewHTTP3Server(r.serverHTTP)
+
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+
+		if errHTTP3 := r.serverHTTP3.ServeListener(r.listenerHTTP3); errHTTP3 != nil && !errors.Is(errHTTP3, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP3))
+		}
+	}()
 	return nil
 }
 
@@ -196,6 +229,14 @@ func (r *otlpReceiver) Start(ctx context.Context, host component.Host) error {
 		return errors.Join(err, r.Shutdown(ctx))
 	}
 
+	if r.health != nil {
+		var limiter memoryLimiter
+		if id := r.cfg.GRPC.MemoryLimiter; id != nil {
+			// The gRPC server was only built if the extension is a memory limiter.
+			limiter, _ = host.GetExtensions()[*id].(memoryLimiter)
+		}
+		r.health.start(host, limiter, r.cfg.GRPC.MemoryLimiterBudget)
+	}
 	return nil
 }
 
@@ -207,6 +248,15 @@ func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 		err = r.serverHTTP.Shutdown(ctx)
 	}
 
+	if r.serverHTTP3 != nil {
+		// The server doesn't always close its listener once shut down.
+		err = errors.Join(err, r.serverHTTP3.Shutdown(ctx), r.listenerHTTP3.Close())
+	}
+
+	if r.health != nil {
+		r.health.shutdown()
+	}
+
 	if r.serverGRPC != nil {
 		r.serverGRPC.GracefulStop()
 	}
@@ -216,17 +266,29 @@ func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 }
 
 func (r *otlpReceiver) registerTraceConsumer(tc consumer.Traces) {
+	if r.health != nil {
+		tc = r.health.traces(tc)
+	}
 	r.nextTraces = tc
 }
 
 func (r *otlpReceiver) registerMetricsConsumer(mc consumer.Metrics) {
+	if r.health != nil {
+		mc = r.health.metrics(mc)
+	}
 	r.nextMetrics = mc
 }
 
 func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) {
+	if r.health != nil {
+		lc = r.health.logs(lc)
+	}
 	r.nextLogs = lc
 }
 
 func (r *otlpReceiver) registerProfilesConsumer(tc xconsumer.Profiles) {
+	if r.health != nil {
+		tc = r.health.profiles(tc)
+	}
 	r.nextProfiles = tc
 }
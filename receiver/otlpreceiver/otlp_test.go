@@ -13,6 +13,10 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 	"testing"
@@ -32,6 +36,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confighttp/xconfighttp"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
@@ -152,6 +157,165 @@ func TestJsonHttp(t *testing.T) {
 	}
 }
 
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestNDJSONClosesBody(t *testing.T) {
+	for _, body := range []string{"{}\n{}", "{}\ninvalid", "{}"} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/traces", nil)
+		rc := &closeRecorder{Reader: strings.NewReader(body)}
+		req.Body = rc
+		rec := httptest.NewRecorder()
+		ok := forEachLineAndCloseBody(rec, req, func(line []byte) (int, error) {
+			if string(line) != "{}" {
+				return http.StatusBadRequest, errors.New("invalid line")
+			}
+			return http.StatusOK, nil
+		})
+		assert.Equal(t, body != "{}\ninvalid", ok, body)
+		assert.True(t, rc.closed, body)
+	}
+}
+
+func TestNDJSONRefusedLine(t *testing.T) {
+	refused := status.Error(codes.Unavailable, "data refused due to high memory usage")
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantCode    codes.Code
+		wantMessage string
+		wantLines   []string
+	}{
+		{
+			name:        "first line",
+			body:        "refused\n{}\n{}",
+			wantStatus:  http.StatusServiceUnavailable,
+			wantCode:    codes.Unavailable,
+			wantMessage: "line 1: data refused due to high memory usage",
+		},
+		{
+			// Retrying the request would duplicate the first line.
+			name:        "middle line",
+			body:        "{}\n\nrefused\n{}",
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    codes.Internal,
+			wantMessage: "line 3: data refused due to high memory usage, the lines before were accepted",
+			wantLines:   []string{"{}"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/traces", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			var lines []string
+			ok := forEachLineAndCloseBody(rec, req, func(line []byte) (int, error) {
+				if string(line) == "refused" {
+					return http.StatusInternalServerError, refused
+				}
+				lines = append(lines, string(line))
+				return http.StatusOK, nil
+			})
+			assert.False(t, ok)
+			assert.Equal(t, tt.wantLines, lines)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			errStatus := &spb.Status{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), errStatus))
+			assert.Equal(t, int32(tt.wantCode), errStatus.Code)
+			assert.Equal(t, tt.wantMessage, errStatus.Message)
+		})
+	}
+}
+
+func TestNDJSONHttp(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := newErrOrSinkConsumer()
+	recv := newHTTPReceiver(t, componenttest.NewNopTelemetrySettings(), addr, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	doNDJSONRequest := func(t *testing.T, url string, encoding string, body []byte, expectedStatusCode int) []byte {
+		resp, err := http.DefaultClient.Do(createHTTPRequest(t, url, encoding, "application/x-ndjson", body))
+		require.NoError(t, err)
+		respBytes, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		require.Equal(t, expectedStatusCode, resp.StatusCode)
+		return respBytes
+	}
+
+	for _, encoding := range []string{"", "gzip"} {
+		t.Run("Success"+encoding, func(t *testing.T) {
+			sink.Reset()
+			for _, dr := range generateDataRequests(t) {
+				// Each line is exported as a separate request, empty lines are skipped.
+				body := bytes.Join([][]byte{dr.jsonBytes, {}, dr.jsonBytes, dr.jsonBytes}, []byte("\n"))
+				respBytes := doNDJSONRequest(t, "http://"+addr+dr.path, encoding, body, http.StatusOK)
+				tr := ptraceotlp.NewExportResponse()
+				require.NoError(t, tr.UnmarshalJSON(respBytes), "Unable to unmarshal response to Response")
+				sink.checkData(t, dr.data, 3)
+			}
+		})
+	}
+
+	t.Run("InvalidLine", func(t *testing.T) {
+		sink.Reset()
+		for _, dr := range generateDataRequests(t) {
+			// The lines before the invalid line are exported.
+			body := bytes.Join([][]byte{dr.jsonBytes, []byte("1234"), dr.jsonBytes}, []byte("\r\n"))
+			respBytes := doNDJSONRequest(t, "http://"+addr+dr.path, "", body, http.StatusBadRequest)
+			errStatus := &spb.Status{}
+			require.NoError(t, json.Unmarshal(respBytes, errStatus))
+			assert.Contains(t, errStatus.Message, "line 2")
+			sink.checkData(t, dr.data, 1)
+		}
+	})
+
+	t.Run("ConsumeError", func(t *testing.T) {
+		sink.Reset()
+		sink.SetConsumeError(errors.New("my error"))
+		for _, dr := range generateDataRequests(t) {
+			respBytes := doNDJSONRequest(t, "http://"+addr+dr.path, "", dr.jsonBytes, http.StatusServiceUnavailable)
+			errStatus := &spb.Status{}
+			require.NoError(t, json.Unmarshal(respBytes, errStatus))
+			assert.Equal(t, int32(codes.Unavailable), errStatus.Code)
+			assert.Equal(t, "line 1: my error", errStatus.Message)
+			sink.checkData(t, dr.data, 0)
+		}
+	})
+}
+
+func TestHTTPPartialSuccess(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := newErrOrSinkConsumer()
+	recv := newHTTPReceiver(t, componenttest.NewNopTelemetrySettings(), addr, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	sink.SetConsumeError(consumererror.NewPermanent(consumererror.NewTraces(errors.New("my error"), testdata.GenerateTraces(1))))
+	dr := generateTracesRequest(t)
+	url := "http://" + addr + dr.path
+
+	respBytes := doHTTPRequest(t, url, "", "application/json", dr.jsonBytes, http.StatusOK)
+	tr := ptraceotlp.NewExportResponse()
+	require.NoError(t, tr.UnmarshalJSON(respBytes))
+	assert.Equal(t, int64(1), tr.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "Permanent error: my error", tr.PartialSuccess().ErrorMessage())
+
+	respBytes = doHTTPRequest(t, url, "", "application/x-protobuf", dr.protoBytes, http.StatusOK)
+	tr = ptraceotlp.NewExportResponse()
+	require.NoError(t, tr.UnmarshalProto(respBytes))
+	assert.Equal(t, int64(1), tr.PartialSuccess().RejectedSpans())
+}
+
 func TestHandleInvalidRequests(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	sink := newErrOrSinkConsumer()
@@ -175,7 +339,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf, application/x-ndjson]",
 		},
 		{
 			name:        "invalid content type",
@@ -184,7 +348,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "invalid",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf, application/x-ndjson]",
 		},
 		{
 			name:        "invalid request",
@@ -217,7 +381,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf, application/x-ndjson]",
 		},
 		{
 			name:        "invalid content type",
@@ -226,7 +390,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "invalid",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf, application/x-ndjson]",
 		},
 		{
 			name:        "invalid request",
@@ -259,7 +423,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf, application/x-ndjson]",
 		},
 		{
 			name:        "invalid content type",
@@ -268,7 +432,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "invalid",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf, application/x-ndjson]",
 		},
 		{
 			name:        "invalid request",
@@ -781,6 +945,92 @@ func TestHTTPInvalidTLSCredentials(t *testing.T) {
 		`failed to load TLS config: failed to load TLS cert and key: for auth via TLS, provide both certificate and key, or neither`)
 }
 
+func TestHTTP3(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC = nil
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.TLSSetting = &configtls.ServerConfig{
+		Config: configtls.Config{
+			CertFile: filepath.Join("testdata", "server.crt"),
+			KeyFile:  filepath.Join("testdata", "server.key"),
+		},
+	}
+	cfg.HTTP.HTTP3 = &xconfighttp.HTTP3Config{Enabled: true}
+	sink := newErrOrSinkConsumer()
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	hcs := &confighttp.ClientConfig{
+		TLSSetting: configtls.ClientConfig{
+			Config: configtls.Config{
+				CAFile: filepath.Join("testdata", "ca.crt"),
+			},
+			ServerName: "localhost",
+		},
+	}
+	client, closer, err := cfg.HTTP.HTTP3.ToClient(context.Background(), hcs, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, closer.Close()) })
+
+	td := testdata.GenerateTraces(2)
+	body, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+	require.NoError(t, err)
+	resp, err := client.Post("https://"+addr+defaultTracesURLPath, "application/x-protobuf", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "HTTP/3.0", resp.Proto)
+	sink.checkData(t, td, 1)
+}
+
+func TestUnixSocketEndpoints(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	dir := t.TempDir()
+	grpcSocket := filepath.Join(dir, "otlp-grpc.sock")
+	httpSocket := filepath.Join(dir, "otlp-http.sock")
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = "unix://" + grpcSocket
+	cfg.GRPC.NetAddr.UnixSocket = confignet.UnixSocketConfig{Permissions: "0600"}
+	cfg.HTTP.Endpoint = "unix://" + httpSocket
+	cfg.HTTP.UnixSocket = &confignet.UnixSocketConfig{Permissions: "0660"}
+	require.NoError(t, component.ValidateConfig(cfg))
+	sink := newErrOrSinkConsumer()
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	fi, err := os.Stat(grpcSocket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+	fi, err = os.Stat(httpSocket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o660), fi.Mode().Perm())
+
+	td := testdata.GenerateTraces(2)
+	cc, err := grpc.NewClient("unix://"+grpcSocket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cc.Close()) })
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(td))
+	require.NoError(t, err)
+	sink.checkData(t, td, 1)
+
+	hcs := &confighttp.ClientConfig{Endpoint: "unix://" + httpSocket}
+	client, err := hcs.ToClient(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(client.CloseIdleConnections)
+	body, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+	require.NoError(t, err)
+	resp, err := client.Post("http://localhost"+defaultTracesURLPath, "application/x-protobuf", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	sink.checkData(t, td, 2)
+}
+
 func testHTTPMaxRequestBodySize(t *testing.T, path string, contentType string, payload []byte, size int, expectedStatusCode int) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	url := "http://" + addr + path
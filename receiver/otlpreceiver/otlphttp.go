@@ -31,6 +31,11 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 		return
 	}
 
+	if enc == ndjsEncoder {
+		handleTracesNDJSON(resp, req, tracesReceiver)
+		return
+	}
+
 	body, ok := readAndCloseBody(resp, req, enc)
 	if !ok {
 		return
@@ -62,6 +67,11 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 		return
 	}
 
+	if enc == ndjsEncoder {
+		handleMetricsNDJSON(resp, req, metricsReceiver)
+		return
+	}
+
 	body, ok := readAndCloseBody(resp, req, enc)
 	if !ok {
 		return
@@ -93,6 +103,11 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 		return
 	}
 
+	if enc == ndjsEncoder {
+		handleLogsNDJSON(resp, req, logsReceiver)
+		return
+	}
+
 	body, ok := readAndCloseBody(resp, req, enc)
 	if !ok {
 		return
@@ -124,6 +139,11 @@ func handleProfiles(resp http.ResponseWriter, req *http.Request, profilesReceive
 		return
 	}
 
+	if enc == ndjsEncoder {
+		handleProfilesNDJSON(resp, req, profilesReceiver)
+		return
+	}
+
 	body, ok := readAndCloseBody(resp, req, enc)
 	if !ok {
 		return
@@ -160,6 +180,8 @@ func readContentType(resp http.ResponseWriter, req *http.Request) (encoder, bool
 		return pbEncoder, true
 	case jsonContentType:
 		return jsEncoder, true
+	case ndjsonContentType:
+		return ndjsEncoder, true
 	default:
 		handleUnmatchedContentType(resp)
 		return nil, false
@@ -198,7 +220,7 @@ func errorHandler(w http.ResponseWriter, r *http.Request, errMsg string, statusC
 	case pbContentType:
 		writeStatusResponse(w, pbEncoder, statusCode, s.Proto())
 		return
-	case jsonContentType:
+	case jsonContentType, ndjsonContentType:
 		writeStatusResponse(w, jsEncoder, statusCode, s.Proto())
 		return
 	}
@@ -237,5 +259,5 @@ func handleUnmatchedMethod(resp http.ResponseWriter) {
 
 func handleUnmatchedContentType(resp http.ResponseWriter) {
 	status := http.StatusUnsupportedMediaType
-	writeResponse(resp, "text/plain", status, []byte(fmt.Sprintf("%v unsupported media type, supported: [%s, %s]", status, jsonContentType, pbContentType)))
+	writeResponse(resp, "text/plain", status, []byte(fmt.Sprintf("%v unsupported media type, supported: [%s, %s, %s]", status, jsonContentType, pbContentType, ndjsonContentType)))
 }
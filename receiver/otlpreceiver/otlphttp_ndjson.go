@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	internalerrors "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/profiles"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
+)
+
+// handleTracesNDJSON exports each line of a newline-delimited JSON body as a separate request,
+// so the body is never held in memory as a whole.
+func handleTracesNDJSON(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver) {
+	otlpResp := ptraceotlp.NewExportResponse()
+	ok := forEachLineAndCloseBody(resp, req, func(line []byte) (int, error) {
+		otlpReq, err := jsEncoder.unmarshalTracesRequest(line)
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		lineResp, err := tracesReceiver.Export(req.Context(), otlpReq)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		ps, lps := otlpResp.PartialSuccess(), lineResp.PartialSuccess()
+		ps.SetRejectedSpans(ps.RejectedSpans() + lps.RejectedSpans())
+		if ps.ErrorMessage() == "" {
+			ps.SetErrorMessage(lps.ErrorMessage())
+		}
+		return http.StatusOK, nil
+	})
+	if !ok {
+		return
+	}
+
+	msg, err := jsEncoder.marshalTracesResponse(otlpResp)
+	if err != nil {
+		writeError(resp, jsEncoder, err, http.StatusInternalServerError)
+		return
+	}
+	writeResponse(resp, jsEncoder.contentType(), http.StatusOK, msg)
+}
+
+// handleMetricsNDJSON exports each line of a newline-delimited JSON body as a separate request,
+// so the body is never held in memory as a whole.
+func handleMetricsNDJSON(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver) {
+	otlpResp := pmetricotlp.NewExportResponse()
+	ok := forEachLineAndCloseBody(resp, req, func(line []byte) (int, error) {
+		otlpReq, err := jsEncoder.unmarshalMetricsRequest(line)
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		lineResp, err := metricsReceiver.Export(req.Context(), otlpReq)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		ps, lps := otlpResp.PartialSuccess(), lineResp.PartialSuccess()
+		ps.SetRejectedDataPoints(ps.RejectedDataPoints() + lps.RejectedDataPoints())
+		if ps.ErrorMessage() == "" {
+			ps.SetErrorMessage(lps.ErrorMessage())
+		}
+		return http.StatusOK, nil
+	})
+	if !ok {
+		return
+	}
+
+	msg, err := jsEncoder.marshalMetricsResponse(otlpResp)
+	if err != nil {
+		writeError(resp, jsEncoder, err, http.StatusInternalServerError)
+		return
+	}
+	writeResponse(resp, jsEncoder.contentType(), http.StatusOK, msg)
+}
+
+// handleLogsNDJSON exports each line of a newline-delimited JSON body as a separate request,
+// so the body is never held in memory as a whole.
+func handleLogsNDJSON(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver) {
+	otlpResp := plogotlp.NewExportResponse()
+	ok := forEachLineAndCloseBody(resp, req, func(line []byte) (int, error) {
+		otlpReq, err := jsEncoder.unmarshalLogsRequest(line)
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		lineResp, err := logsReceiver.Export(req.Context(), otlpReq)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		ps, lps := otlpResp.PartialSuccess(), lineResp.PartialSuccess()
+		ps.SetRejectedLogRecords(ps.RejectedLogRecords() + lps.RejectedLogRecords())
+		if ps.ErrorMessage() == "" {
+			ps.SetErrorMessage(lps.ErrorMessage())
+		}
+		return http.StatusOK, nil
+	})
+	if !ok {
+		return
+	}
+
+	msg, err := jsEncoder.marshalLogsResponse(otlpResp)
+	if err != nil {
+		writeError(resp, jsEncoder, err, http.StatusInternalServerError)
+		return
+	}
+	writeResponse(resp, jsEncoder.contentType(), http.StatusOK, msg)
+}
+
+// handleProfilesNDJSON exports each line of a newline-delimited JSON body as a separate request,
+// so the body is never held in memory as a whole.
+func handleProfilesNDJSON(resp http.ResponseWriter, req *http.Request, profilesReceiver *profiles.Receiver) {
+	otlpResp := pprofileotlp.NewExportResponse()
+	ok := forEachLineAndCloseBody(resp, req, func(line []byte) (int, error) {
+		otlpReq, err := jsEncoder.unmarshalProfilesRequest(line)
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		lineResp, err := profilesReceiver.Export(req.Context(), otlpReq)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		ps, lps := otlpResp.PartialSuccess(), lineResp.PartialSuccess()
+		ps.SetRejectedProfiles(ps.RejectedProfiles() + lps.RejectedProfiles())
+		if ps.ErrorMessage() == "" {
+			ps.SetErrorMessage(lps.ErrorMessage())
+		}
+		return http.StatusOK, nil
+	})
+	if !ok {
+		return
+	}
+
+	msg, err := jsEncoder.marshalProfilesResponse(otlpResp)
+	if err != nil {
+		writeError(resp, jsEncoder, err, http.StatusInternalServerError)
+		return
+	}
+	writeResponse(resp, jsEncoder.contentType(), http.StatusOK, msg)
+}
+
+// forEachLineAndCloseBody calls f with each non-empty line of the body, as it is read,
+// then closes the body, including when reading fails.
+// On failure it writes the error returned by f, with the status code returned by f
+// and the number of the failed line, and stops reading. The lines before were
+// already processed, so a retryable error is made permanent once a line was
+// processed, since retrying the request would duplicate them.
+func forEachLineAndCloseBody(resp http.ResponseWriter, req *http.Request, f func(line []byte) (int, error)) bool {
+	ok := forEachLine(resp, req.Body, f)
+	if err := req.Body.Close(); err != nil && ok {
+		writeError(resp, jsEncoder, err, http.StatusBadRequest)
+		return false
+	}
+	return ok
+}
+
+// forEachLine calls f with each non-empty line of body, see forEachLineAndCloseBody.
+// The lines are only bounded by the size of the body, which confighttp limits to
+// max_request_body_size.
+func forEachLine(resp http.ResponseWriter, body io.Reader, f func(line []byte) (int, error)) bool {
+	r := bufio.NewReader(body)
+	processed := false
+	for lineNum := 1; ; lineNum++ {
+		line, err := r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			writeError(resp, jsEncoder, err, http.StatusBadRequest)
+			return false
+		}
+		if trimmed := bytes.TrimSpace(line); len(trimmed) != 0 {
+			if statusCode, ferr := f(trimmed); ferr != nil {
+				switch s, ok := status.FromError(ferr); {
+				case ok && processed && isRetryable(s):
+					ferr = status.Errorf(codes.Internal, "line %d: %s, the lines before were accepted", lineNum, s.Message())
+				case ok:
+					ferr = status.Errorf(s.Code(), "line %d: %s", lineNum, s.Message())
+				default:
+					ferr = fmt.Errorf("line %d: %w", lineNum, ferr)
+				}
+				writeError(resp, jsEncoder, ferr, statusCode)
+				return false
+			}
+			processed = true
+		}
+		if errors.Is(err, io.EOF) {
+			return true
+		}
+	}
+}
+
+// isRetryable returns whether the status is mapped to a retryable HTTP status code.
+func isRetryable(s *status.Status) bool {
+	switch internalerrors.GetHTTPStatusCodeFromStatus(s) {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	}
+	return false
+}
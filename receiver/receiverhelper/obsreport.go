@@ -7,6 +7,7 @@ package receiverhelper // import "go.opentelemetry.io/collector/receiver/receive
 
 import (
 	"context"
+	"errors"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -14,6 +15,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/internal"
@@ -160,6 +162,12 @@ func (rec *ObsReport) endOp(
 	if err != nil {
 		numAccepted = 0
 		numRefused = numReceivedItems
+		// A permanent error carrying a part of the data only refuses these items,
+		// the others were accepted by the pipeline.
+		if rejected := rejectedItems(err, signal); consumererror.IsPermanent(err) && rejected > 0 && rejected < numReceivedItems {
+			numAccepted = numReceivedItems - rejected
+			numRefused = rejected
+		}
 	}
 
 	span := trace.SpanFromContext(receiverCtx)
@@ -193,6 +201,29 @@ func (rec *ObsReport) endOp(
 	span.End()
 }
 
+// rejectedItems returns the number of items carried by err, if it is the error
+// type of signal, or 0.
+func rejectedItems(err error, signal pipeline.Signal) int {
+	switch signal {
+	case pipeline.SignalTraces:
+		var e consumererror.Traces
+		if errors.As(err, &e) {
+			return e.Data().SpanCount()
+		}
+	case pipeline.SignalMetrics:
+		var e consumererror.Metrics
+		if errors.As(err, &e) {
+			return e.Data().DataPointCount()
+		}
+	case pipeline.SignalLogs:
+		var e consumererror.Logs
+		if errors.As(err, &e) {
+			return e.Data().LogRecordCount()
+		}
+	}
+	return 0
+}
+
 func (rec *ObsReport) recordMetrics(receiverCtx context.Context, signal pipeline.Signal, numAccepted, numRefused int) {
 	var acceptedMeasure, refusedMeasure metric.Int64Counter
 	switch signal {
@@ -15,6 +15,8 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/internal"
 )
@@ -296,6 +298,36 @@ func TestCheckReceiverLogsViews(t *testing.T) {
 	assert.Error(t, tt.CheckReceiverLogs(transport, 0, 7))
 }
 
+func TestCheckReceiverPartiallyRefused(t *testing.T) {
+	tt, err := componenttest.SetupTelemetry(receiverID)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	rec, err := NewObsReport(ObsReportSettings{
+		ReceiverID:             receiverID,
+		Transport:              transport,
+		ReceiverCreateSettings: receiver.Settings{ID: receiverID, TelemetrySettings: tt.TelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
+	})
+	require.NoError(t, err)
+
+	ctx := rec.StartTracesOp(context.Background())
+	rec.EndTracesOp(ctx, format, 7, consumererror.NewPermanent(consumererror.NewTraces(errFake, testdata.GenerateTraces(2))))
+	require.NoError(t, tt.CheckReceiverTraces(transport, 5, 2))
+
+	ctx = rec.StartMetricsOp(context.Background())
+	rec.EndMetricsOp(ctx, format, 7, consumererror.NewPermanent(consumererror.NewMetrics(errFake, testdata.GenerateMetrics(1))))
+	require.NoError(t, tt.CheckReceiverMetrics(transport, 5, 2))
+
+	ctx = rec.StartLogsOp(context.Background())
+	rec.EndLogsOp(ctx, format, 7, consumererror.NewPermanent(consumererror.NewLogs(errFake, testdata.GenerateLogs(2))))
+	require.NoError(t, tt.CheckReceiverLogs(transport, 5, 2))
+
+	// Retryable errors refuse all the items, as the client retries all of them.
+	ctx = rec.StartLogsOp(context.Background())
+	rec.EndLogsOp(ctx, format, 7, consumererror.NewLogs(errFake, testdata.GenerateLogs(2)))
+	assert.NoError(t, tt.CheckReceiverLogs(transport, 5, 9))
+}
+
 func testTelemetry(t *testing.T, id component.ID, testFunc func(t *testing.T, tt componenttest.TestTelemetry)) {
 	tt, err := componenttest.SetupTelemetry(id)
 	require.NoError(t, err)
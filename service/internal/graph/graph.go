@@ -26,6 +26,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/connector/xconnector"
 	"go.opentelemetry.io/collector/consumer"
@@ -66,28 +67,39 @@ type Graph struct {
 	instanceIDs map[int64]*componentstatus.InstanceID
 
 	telemetry component.TelemetrySettings
+
+	// Copy of the obsreport counters of the components, nil if they are not recorded.
+	throughput *throughput
 }
 
 // Build builds a full pipeline graph.
 // Build also validates the configuration of the pipelines and does the actual initialization of each Component in the Graph.
 func Build(ctx context.Context, set Settings) (*Graph, error) {
-	pipelines := &Graph{
+	pipelines := newGraph(set)
+	if set.Telemetry.MetricsLevel >= configtelemetry.LevelBasic {
+		pipelines.throughput = newThroughput()
+	}
+	if err := pipelines.createNodes(set); err != nil {
+		return nil, err
+	}
+	pipelines.createEdges()
+	return pipelines, pipelines.buildComponents(ctx, set)
+}
+
+func newGraph(set Settings) *Graph {
+	g := &Graph{
 		componentGraph: simple.NewDirectedGraph(),
 		pipelines:      make(map[pipeline.ID]*pipelineNodes, len(set.PipelineConfigs)),
 		instanceIDs:    make(map[int64]*componentstatus.InstanceID),
 		telemetry:      set.Telemetry,
 	}
 	for pipelineID := range set.PipelineConfigs {
-		pipelines.pipelines[pipelineID] = &pipelineNodes{
+		g.pipelines[pipelineID] = &pipelineNodes{
 			receivers: make(map[int64]graph.Node),
 			exporters: make(map[int64]graph.Node),
 		}
 	}
-	if err := pipelines.createNodes(set); err != nil {
-		return nil, err
-	}
-	pipelines.createEdges()
-	return pipelines, pipelines.buildComponents(ctx, set)
+	return g
 }
 
 // Creates a node for each instance of a component and adds it to the graph.
@@ -285,17 +297,19 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 
 	for i := len(nodes) - 1; i >= 0; i-- {
 		node := nodes[i]
+		tel := set.Telemetry
+		tel.MeterProvider = g.throughput.meterProvider(tel.MeterProvider, node)
 
 		switch n := node.(type) {
 		case *receiverNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
+			err = n.buildComponent(ctx, tel, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
 		case *processorNode:
 			// nextConsumers is guaranteed to be length 1.  Either it is the next processor or it is the fanout node for the exporters.
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
+			err = n.buildComponent(ctx, tel, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
 		case *exporterNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder)
+			err = n.buildComponent(ctx, tel, set.BuildInfo, set.ExporterBuilder)
 		case *connectorNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
+			err = n.buildComponent(ctx, tel, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
 		case *capabilitiesNode:
 			capability := consumer.Capabilities{
 				// The fanOutNode represents the aggregate capabilities of the exporters in the pipeline.
@@ -476,7 +490,7 @@ func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error
 			componentstatus.NewEvent(componentstatus.StatusStopped),
 		)
 	}
-	return errs
+	return multierr.Append(errs, g.throughput.shutdown(ctx))
 }
 
 func (g *Graph) GetExporters() map[pipeline.Signal]map[component.ID]component.Component {
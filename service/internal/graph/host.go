@@ -4,11 +4,25 @@
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"mime"
 	"net/http"
+	"net/url"
 	"path"
 	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/extension"
@@ -47,6 +61,16 @@ type Host struct {
 	ServiceExtensions *extensions.Extensions
 
 	Reporter status.Reporter
+
+	// Logger is used to log the changes made from the zPages.
+	Logger *zap.Logger
+
+	// featureGateToggle is true if feature gates can be set at runtime from the featurez page.
+	featureGateToggle atomic.Bool
+	// featureGateToken is sent with the forms of the featurez page, so that the pages of
+	// other origins, which can't read it, can't set feature gates.
+	featureGateToken     string
+	featureGateTokenOnce sync.Once
 }
 
 func (host *Host) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
@@ -88,10 +112,12 @@ func (host *Host) NotifyComponentStatusChange(source *componentstatus.InstanceID
 
 const (
 	// Paths
-	zServicePath   = "servicez"
-	zPipelinePath  = "pipelinez"
-	zExtensionPath = "extensionz"
-	zFeaturePath   = "featurez"
+	zServicePath    = "servicez"
+	zPipelinePath   = "pipelinez"
+	zExtensionPath  = "extensionz"
+	zFeaturePath    = "featurez"
+	zTopologyPath   = "topologyz"
+	zThroughputPath = "throughputz"
 )
 
 // InfoVar is a singleton instance of the Info struct.
@@ -111,7 +137,9 @@ func (host *Host) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, zServicePath), host.zPagesRequest)
 	mux.HandleFunc(path.Join(pathPrefix, zPipelinePath), host.Pipelines.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zExtensionPath), host.ServiceExtensions.HandleZPages)
-	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), host.handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zTopologyPath), host.Pipelines.HandleTopologyZPages)
+	mux.HandleFunc(path.Join(pathPrefix, zThroughputPath), host.Pipelines.HandleThroughputZPages)
 }
 
 func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
@@ -124,6 +152,16 @@ func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 		ComponentEndpoint: zPipelinePath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Topology",
+		ComponentEndpoint: zTopologyPath,
+		Link:              true,
+	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Throughput",
+		ComponentEndpoint: zThroughputPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 		Name:              "Extensions",
 		ComponentEndpoint: zExtensionPath,
@@ -137,24 +175,140 @@ func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 	zpages.WriteHTMLPageFooter(w)
 }
 
-func handleFeaturezRequest(w http.ResponseWriter, _ *http.Request) {
+// EnableFeatureGateToggle allows the feature gates registered with featuregate.WithRegisterRuntimeToggle
+// to be enabled and disabled from the featurez page. It must only be called by extensions serving
+// the zPages behind an authenticated endpoint.
+func (host *Host) EnableFeatureGateToggle() {
+	host.featureGateTokenOnce.Do(func() {
+		token := make([]byte, 32)
+		_, _ = rand.Read(token)
+		host.featureGateToken = hex.EncodeToString(token)
+	})
+	host.featureGateToggle.Store(true)
+}
+
+func (host *Host) handleFeaturezRequest(w http.ResponseWriter, r *http.Request) {
+	host.handleFeaturez(w, r, featuregate.GlobalRegistry())
+}
+
+func (host *Host) handleFeaturez(w http.ResponseWriter, r *http.Request, reg *featuregate.Registry) {
+	toggle := host.featureGateToggle.Load()
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPost:
+		if !toggle {
+			http.Error(w, "feature gates can not be set at runtime, it must be enabled by the extension serving the zPages", http.StatusForbidden)
+			return
+		}
+		if err := host.checkFeatureGateRequest(r); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		host.setFeatureGate(w, r, reg)
+		return
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Feature Gates"})
-	zpages.WriteHTMLFeaturesTable(w, getFeaturesTableData())
+	data := getFeaturesTableData(reg, toggle)
+	if toggle {
+		data.Token = host.featureGateToken
+	}
+	zpages.WriteHTMLFeaturesTable(w, data)
 	zpages.WriteHTMLPageFooter(w)
 }
 
-func getFeaturesTableData() zpages.FeatureGateTableData {
+// checkFeatureGateRequest protects the requests setting feature gates against cross-site request
+// forgery: they must come from the same origin, if the browser tells it, and either be sent with
+// the token of the featurez page, or have a JSON body, which browsers only send cross-origin
+// after a preflight request the zPages don't allow.
+func (host *Host) checkFeatureGateRequest(r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		origin = r.Header.Get("Referer")
+	}
+	if origin != "" {
+		u, err := url.Parse(origin)
+		if err != nil || u.Host != r.Host {
+			return errors.New("feature gates can not be set from another origin")
+		}
+	}
+	if isJSON(r) {
+		return nil
+	}
+	if subtle.ConstantTimeCompare([]byte(r.FormValue("token")), []byte(host.featureGateToken)) != 1 {
+		return errors.New("missing or invalid featurez token, feature gates must be set from the featurez page or with a JSON body")
+	}
+	return nil
+}
+
+func isJSON(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// featureGateRequest is the JSON body of the requests setting feature gates.
+type featureGateRequest struct {
+	ID      string `json:"id"`
+	Enabled *bool  `json:"enabled"`
+}
+
+// setFeatureGate sets the feature gate identified by the "id" form value to the "enabled" form value,
+// or by the "id" and "enabled" fields of the JSON body, then redirects to the featurez page.
+func (host *Host) setFeatureGate(w http.ResponseWriter, r *http.Request, reg *featuregate.Registry) {
+	var id string
+	var enabled bool
+	if isJSON(r) {
+		var req featureGateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Enabled == nil {
+			http.Error(w, "invalid body, must be a JSON object with \"id\" and \"enabled\" fields", http.StatusBadRequest)
+			return
+		}
+		id, enabled = req.ID, *req.Enabled
+	} else {
+		var err error
+		id = r.FormValue("id")
+		if enabled, err = strconv.ParseBool(r.FormValue("enabled")); err != nil {
+			http.Error(w, "invalid \"enabled\" value: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	changed, err := reg.SetAtRuntime(id, enabled)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if changed {
+		trace.SpanFromContext(r.Context()).AddEvent("Feature gate set at runtime", trace.WithAttributes(
+			attribute.String("feature_gate", id),
+			attribute.Bool("enabled", enabled)))
+		if host.Logger != nil {
+			host.Logger.Info("Feature gate set at runtime",
+				zap.String("feature_gate", id),
+				zap.Bool("enabled", enabled),
+				zap.String("remote_addr", r.RemoteAddr))
+		}
+	}
+	http.Redirect(w, r, r.URL.Path, http.StatusSeeOther)
+}
+
+func getFeaturesTableData(reg *featuregate.Registry, toggle bool) zpages.FeatureGateTableData {
 	data := zpages.FeatureGateTableData{}
-	featuregate.GlobalRegistry().VisitAll(func(gate *featuregate.Gate) {
+	reg.VisitAll(func(gate *featuregate.Gate) {
+		stage := gate.Stage()
 		data.Rows = append(data.Rows, zpages.FeatureGateTableRowData{
 			ID:           gate.ID(),
 			Enabled:      gate.IsEnabled(),
 			Description:  gate.Description(),
-			Stage:        gate.Stage().String(),
+			Stage:        stage.String(),
 			FromVersion:  gate.FromVersion(),
 			ToVersion:    gate.ToVersion(),
 			ReferenceURL: gate.ReferenceURL(),
+			Toggle:       toggle && gate.RuntimeToggle() && (stage == featuregate.StageAlpha || stage == featuregate.StageBeta),
 		})
 	})
 	return data
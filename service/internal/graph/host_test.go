@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func newFeaturezRequest(method string, form url.Values) *http.Request {
+	req := httptest.NewRequest(method, "/debug/featurez", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestHandleFeaturez(t *testing.T) {
+	reg := featuregate.NewRegistry()
+	alpha := reg.MustRegister("alpha", featuregate.StageAlpha, featuregate.WithRegisterRuntimeToggle())
+	reg.MustRegister("beta", featuregate.StageBeta)
+	core, logs := observer.New(zapcore.InfoLevel)
+	host := &Host{Logger: zap.New(core)}
+
+	rec := httptest.NewRecorder()
+	host.handleFeaturez(rec, newFeaturezRequest(http.MethodGet, nil), reg)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "alpha")
+	assert.NotContains(t, rec.Body.String(), "<form")
+
+	rec = httptest.NewRecorder()
+	host.handleFeaturez(rec, newFeaturezRequest(http.MethodPost, url.Values{"id": {"alpha"}, "enabled": {"true"}}), reg)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.False(t, alpha.IsEnabled())
+
+	host.EnableFeatureGateToggle()
+	rec = httptest.NewRecorder()
+	host.handleFeaturez(rec, newFeaturezRequest(http.MethodGet, nil), reg)
+	assert.Equal(t, 1, strings.Count(rec.Body.String(), "<form"))
+	assert.Contains(t, rec.Body.String(), `value="Enable"`)
+	assert.Contains(t, rec.Body.String(), `name="token" value="`+host.featureGateToken+`"`)
+
+	// The forms must be sent with the token of the page.
+	rec = httptest.NewRecorder()
+	host.handleFeaturez(rec, newFeaturezRequest(http.MethodPost, url.Values{"id": {"alpha"}, "enabled": {"true"}}), reg)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.False(t, alpha.IsEnabled())
+
+	recorder := tracetest.NewSpanRecorder()
+	ctx, span := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test").Start(context.Background(), "featurez")
+	rec = httptest.NewRecorder()
+	host.handleFeaturez(rec, newFeaturezRequest(http.MethodPost, url.Values{"id": {"alpha"}, "enabled": {"true"}, "token": {host.featureGateToken}}).WithContext(ctx), reg)
+	span.End()
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/debug/featurez", rec.Header().Get("Location"))
+	assert.True(t, alpha.IsEnabled())
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "Feature gate set at runtime", entry.Message)
+	assert.Equal(t, map[string]any{"feature_gate": "alpha", "enabled": true, "remote_addr": "192.0.2.1:1234"}, entry.ContextMap())
+	require.Len(t, recorder.Ended(), 1)
+	events := recorder.Ended()[0].Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, "Feature gate set at runtime", events[0].Name)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("feature_gate", "alpha"), attribute.Bool("enabled", true)}, events[0].Attributes)
+
+	// Setting the current value is not logged.
+	rec = httptest.NewRecorder()
+	host.handleFeaturez(rec, newFeaturezRequest(http.MethodPost, url.Values{"id": {"alpha"}, "enabled": {"true"}, "token": {host.featureGateToken}}), reg)
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, 1, logs.Len())
+
+	rec = httptest.NewRecorder()
+	host.handleFeaturez(rec, newFeaturezRequest(http.MethodPost, url.Values{"id": {"beta"}, "enabled": {"false"}, "token": {host.featureGateToken}}), reg)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "does not support being set at runtime")
+
+	rec = httptest.NewRecorder()
+	host.handleFeaturez(rec, newFeaturezRequest(http.MethodPost, url.Values{"id": {"alpha"}, "enabled": {"maybe"}, "token": {host.featureGateToken}}), reg)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// JSON bodies aren't sent cross-origin without a preflight request, and don't need the token.
+	req := httptest.NewRequest(http.MethodPost, "/debug/featurez", strings.NewReader(`{"id":"alpha","enabled":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	host.handleFeaturez(rec, req, reg)
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.False(t, alpha.IsEnabled())
+
+	req = httptest.NewRequest(http.MethodPost, "/debug/featurez", strings.NewReader(`{"id":"alpha"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	host.handleFeaturez(rec, req, reg)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// Requests from other origins are rejected, even with the token.
+	for _, header := range []string{"Origin", "Referer"} {
+		req = newFeaturezRequest(http.MethodPost, url.Values{"id": {"alpha"}, "enabled": {"true"}, "token": {host.featureGateToken}})
+		req.Header.Set(header, "https://attacker.example.com/page")
+		rec = httptest.NewRecorder()
+		host.handleFeaturez(rec, req, reg)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.False(t, alpha.IsEnabled())
+	}
+
+	req = newFeaturezRequest(http.MethodPost, url.Values{"id": {"alpha"}, "enabled": {"true"}, "token": {host.featureGateToken}})
+	req.Header.Set("Origin", "http://example.com")
+	rec = httptest.NewRecorder()
+	host.handleFeaturez(rec, req, reg)
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.True(t, alpha.IsEnabled())
+
+	rec = httptest.NewRecorder()
+	host.handleFeaturez(rec, newFeaturezRequest(http.MethodDelete, nil), reg)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
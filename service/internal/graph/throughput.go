@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"gonum.org/v1/gonum/graph"
+)
+
+// throughputNodeKey is the attribute identifying the graph node that recorded a measurement.
+const throughputNodeKey = "graph.node"
+
+// Prefixes of the counters recorded by receiverhelper, processorhelper and exporterhelper
+// that are used to compute the throughput of the edges.
+const (
+	receiverAcceptedPrefix      = "otelcol_receiver_accepted_"
+	receiverRefusedPrefix       = "otelcol_receiver_refused_"
+	processorIncomingItems      = "otelcol_processor_incoming_items"
+	processorOutgoingItems      = "otelcol_processor_outgoing_items"
+	exporterSentPrefix          = "otelcol_exporter_sent_"
+	exporterSendFailedPrefix    = "otelcol_exporter_send_failed_"
+	exporterEnqueueFailedPrefix = "otelcol_exporter_enqueue_failed_"
+)
+
+func isThroughputCounter(name string) bool {
+	for _, prefix := range []string{
+		receiverAcceptedPrefix, receiverRefusedPrefix,
+		processorIncomingItems, processorOutgoingItems,
+		exporterSentPrefix, exporterSendFailedPrefix, exporterEnqueueFailedPrefix,
+	} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// throughput keeps an in-memory copy of the obsreport counters recorded by the components
+// of the graph, so that the items flowing through each edge can be displayed without
+// a metrics backend.
+type throughput struct {
+	reader   *sdkmetric.ManualReader
+	provider *sdkmetric.MeterProvider
+}
+
+func newThroughput() *throughput {
+	reader := sdkmetric.NewManualReader()
+	return &throughput{
+		reader:   reader,
+		provider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	}
+}
+
+// meterProvider returns a MeterProvider recording to mp, that also records the obsreport
+// counters of the component built for node into t.
+func (t *throughput) meterProvider(mp metric.MeterProvider, node graph.Node) metric.MeterProvider {
+	if t == nil {
+		return mp
+	}
+	return &teeMeterProvider{
+		MeterProvider: mp,
+		secondary:     t.provider,
+		nodeAttr:      metric.WithAttributes(attribute.String(throughputNodeKey, topologyNodeID(node))),
+	}
+}
+
+// nodeCounts is the sum of the obsreport counters recorded by a node, by metric name.
+type nodeCounts map[string]int64
+
+func (c nodeCounts) sum(prefixes ...string) (int64, bool) {
+	var total int64
+	found := false
+	for name, v := range c {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(name, prefix) {
+				total += v
+				found = true
+			}
+		}
+	}
+	return total, found
+}
+
+// collect returns the counters recorded so far, by node ID.
+func (t *throughput) collect(ctx context.Context) (map[string]nodeCounts, error) {
+	var rm metricdata.ResourceMetrics
+	if err := t.reader.Collect(ctx, &rm); err != nil {
+		return nil, err
+	}
+	counts := make(map[string]nodeCounts)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+			for _, dp := range sum.DataPoints {
+				node, ok := dp.Attributes.Value(throughputNodeKey)
+				if !ok {
+					continue
+				}
+				if counts[node.AsString()] == nil {
+					counts[node.AsString()] = nodeCounts{}
+				}
+				counts[node.AsString()][m.Name] += dp.Value
+			}
+		}
+	}
+	return counts, nil
+}
+
+func (t *throughput) shutdown(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	return t.provider.Shutdown(ctx)
+}
+
+// EdgeThroughput is the number of items that went through an edge of the graph.
+type EdgeThroughput struct {
+	From, To TopologyNode
+	// Known is false if none of the nodes of the edge records items.
+	Known  bool
+	Items  int64
+	Errors int64
+}
+
+// Throughput returns the items and errors counted so far for each edge of the graph,
+// sorted like the edges of the Topology. Counts are derived from the nodes next to the edge:
+//   - the items accepted and refused by a source receiver;
+//   - the incoming items of a destination processor, or the outgoing items of a source processor;
+//   - the items sent and failed to be sent or enqueued by a destination exporter.
+//
+// Receivers and exporters shared by several pipelines report the same counts for all their edges.
+// It returns nil if the obsreport counters are not recorded, for example because the
+// telemetry metrics level is none.
+func (g *Graph) Throughput(ctx context.Context) ([]EdgeThroughput, error) {
+	if g.throughput == nil {
+		return nil, nil
+	}
+	counts, err := g.throughput.collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	topology := g.Topology()
+	edges := make([]EdgeThroughput, 0, len(topology.Edges))
+	for _, e := range topology.Edges {
+		et := EdgeThroughput{From: topology.node(e.From), To: topology.node(e.To)}
+		from, to := counts[e.From], counts[e.To]
+		switch {
+		case et.To.Kind == "processor":
+			et.Items, et.Known = to.sum(processorIncomingItems)
+		case et.From.Kind == "processor":
+			et.Items, et.Known = from.sum(processorOutgoingItems)
+		case et.From.Kind == "receiver":
+			accepted, okAccepted := from.sum(receiverAcceptedPrefix)
+			refused, okRefused := from.sum(receiverRefusedPrefix)
+			et.Items, et.Errors, et.Known = accepted, refused, okAccepted || okRefused
+		case et.To.Kind == "exporter":
+			sent, okSent := to.sum(exporterSentPrefix)
+			failed, okFailed := to.sum(exporterSendFailedPrefix, exporterEnqueueFailedPrefix)
+			et.Items, et.Errors, et.Known = sent, failed, okSent || okFailed
+		}
+		edges = append(edges, et)
+	}
+	return edges, nil
+}
+
+// teeMeterProvider creates meters whose obsreport counters also record to secondary.
+type teeMeterProvider struct {
+	metric.MeterProvider
+	secondary metric.MeterProvider
+	nodeAttr  metric.MeasurementOption
+}
+
+func (p *teeMeterProvider) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	return &teeMeter{
+		Meter:     p.MeterProvider.Meter(name, opts...),
+		secondary: p.secondary.Meter(name, opts...),
+		nodeAttr:  p.nodeAttr,
+	}
+}
+
+type teeMeter struct {
+	metric.Meter
+	secondary metric.Meter
+	nodeAttr  metric.MeasurementOption
+}
+
+func (m *teeMeter) Int64Counter(name string, opts ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	counter, err := m.Meter.Int64Counter(name, opts...)
+	if err != nil || !isThroughputCounter(name) {
+		return counter, err
+	}
+	secondary, err := m.secondary.Int64Counter(name, opts...)
+	if err != nil {
+		// The copy is best effort, it must not prevent the component from being built.
+		return counter, nil
+	}
+	return &teeInt64Counter{Int64Counter: counter, secondary: secondary, nodeAttr: m.nodeAttr}, nil
+}
+
+type teeInt64Counter struct {
+	metric.Int64Counter
+	secondary metric.Int64Counter
+	nodeAttr  metric.MeasurementOption
+}
+
+func (c *teeInt64Counter) Add(ctx context.Context, incr int64, opts ...metric.AddOption) {
+	c.Int64Counter.Add(ctx, incr, opts...)
+	c.secondary.Add(ctx, incr, append(opts, c.nodeAttr)...)
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func newThroughputTestGraph(t *testing.T) *Graph {
+	set := newTopologyTestSettings()
+	g := newGraph(set)
+	require.NoError(t, g.createNodes(set))
+	g.createEdges()
+	g.throughput = newThroughput()
+	return g
+}
+
+// addCount records incr on the counter name as if it was recorded by the component of node.
+func addCount(t *testing.T, g *Graph, node interface{ ID() int64 }, name string, incr int64) {
+	mp := g.throughput.meterProvider(sdkmetric.NewMeterProvider(), g.componentGraph.Node(node.ID()))
+	counter, err := mp.Meter("test").Int64Counter(name)
+	require.NoError(t, err)
+	counter.Add(context.Background(), incr, metric.WithAttributes(attribute.String("transport", "test")))
+}
+
+func TestThroughput(t *testing.T) {
+	g := newThroughputTestGraph(t)
+	traces := g.pipelines[pipeline.NewID(pipeline.SignalTraces)]
+
+	addCount(t, g, newReceiverNode(pipeline.SignalTraces, component.MustNewID("examplereceiver")), "otelcol_receiver_accepted_spans", 10)
+	addCount(t, g, newReceiverNode(pipeline.SignalTraces, component.MustNewID("examplereceiver")), "otelcol_receiver_refused_spans", 2)
+	addCount(t, g, traces.processors[0], "otelcol_processor_incoming_items", 10)
+	addCount(t, g, traces.processors[0], "otelcol_processor_outgoing_items", 7)
+	addCount(t, g, newExporterNode(pipeline.SignalTraces, component.MustNewID("exampleexporter")), "otelcol_exporter_sent_spans", 5)
+	addCount(t, g, newExporterNode(pipeline.SignalTraces, component.MustNewID("exampleexporter")), "otelcol_exporter_send_failed_spans", 1)
+	addCount(t, g, newExporterNode(pipeline.SignalTraces, component.MustNewID("exampleexporter")), "otelcol_exporter_enqueue_failed_spans", 1)
+	// Not an obsreport counter, ignored.
+	addCount(t, g, newExporterNode(pipeline.SignalTraces, component.MustNewID("exampleexporter")), "otelcol_exporter_custom", 100)
+
+	edges, err := g.Throughput(context.Background())
+	require.NoError(t, err)
+
+	type edgeCounts struct {
+		from, to      string
+		known         bool
+		items, errors int64
+	}
+	got := make([]edgeCounts, 0, len(edges))
+	for _, e := range edges {
+		got = append(got, edgeCounts{from: e.From.ID, to: e.To.ID, known: e.Known, items: e.Items, errors: e.Errors})
+	}
+	assert.Equal(t, []edgeCounts{
+		{from: "capabilities/metrics", to: "fanout/metrics"},
+		{from: "capabilities/traces", to: "processor/traces/exampleprocessor", known: true, items: 10},
+		{from: "connector/traces/metrics/exampleconnector", to: "capabilities/metrics"},
+		{from: "fanout/metrics", to: "exporter/metrics/exampleexporter"},
+		{from: "fanout/traces", to: "connector/traces/metrics/exampleconnector"},
+		{from: "fanout/traces", to: "exporter/traces/exampleexporter", known: true, items: 5, errors: 2},
+		{from: "processor/traces/exampleprocessor", to: "fanout/traces", known: true, items: 7},
+		{from: "receiver/traces/examplereceiver", to: "capabilities/traces", known: true, items: 10, errors: 2},
+	}, got)
+}
+
+func TestThroughputDisabled(t *testing.T) {
+	g := newThroughputTestGraph(t)
+	g.throughput = nil
+	edges, err := g.Throughput(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, edges)
+
+	mp := sdkmetric.NewMeterProvider()
+	assert.Same(t, mp, g.throughput.meterProvider(mp, newFanOutNode(pipeline.NewID(pipeline.SignalTraces))))
+	require.NoError(t, g.throughput.shutdown(context.Background()))
+}
+
+func TestTeeMeterProvider(t *testing.T) {
+	primaryReader := sdkmetric.NewManualReader()
+	primary := sdkmetric.NewMeterProvider(sdkmetric.WithReader(primaryReader))
+	tp := newThroughput()
+	mp := tp.meterProvider(primary, newFanOutNode(pipeline.NewID(pipeline.SignalTraces)))
+
+	meter := mp.Meter("test")
+	accepted, err := meter.Int64Counter("otelcol_receiver_accepted_spans")
+	require.NoError(t, err)
+	accepted.Add(context.Background(), 3)
+	other, err := meter.Int64Counter("other")
+	require.NoError(t, err)
+	other.Add(context.Background(), 1)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, primaryReader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Len(t, rm.ScopeMetrics[0].Metrics, 2)
+
+	counts, err := tp.collect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]nodeCounts{
+		"fanout/traces": {"otelcol_receiver_accepted_spans": 3},
+	}, counts)
+	require.NoError(t, tp.shutdown(context.Background()))
+}
+
+func TestHandleThroughputZPages(t *testing.T) {
+	g := newThroughputTestGraph(t)
+	addCount(t, g, newReceiverNode(pipeline.SignalTraces, component.MustNewID("examplereceiver")), "otelcol_receiver_refused_spans", 4)
+
+	rec := httptest.NewRecorder()
+	g.HandleThroughputZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/throughputz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "receiver: examplereceiver [traces]")
+	assert.Contains(t, rec.Body.String(), `<td style="text-align: right; color: red">4</td>`)
+
+	g.throughput = nil
+	rec = httptest.NewRecorder()
+	g.HandleThroughputZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/throughputz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "metrics::level is basic or higher")
+}
@@ -0,0 +1,294 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Format is a format in which a Topology can be rendered.
+type Format string
+
+const (
+	FormatDOT     Format = "dot"
+	FormatMermaid Format = "mermaid"
+	FormatJSON    Format = "json"
+)
+
+// Formats lists the supported topology formats.
+var Formats = []Format{FormatDOT, FormatMermaid, FormatJSON}
+
+// Topology is a description of the nodes of a pipeline graph and the edges between them,
+// independent of the components instantiated for each node.
+type Topology struct {
+	// Nodes sorted by ID.
+	Nodes []TopologyNode `json:"nodes"`
+	// Edges sorted by source and then destination ID.
+	Edges []TopologyEdge `json:"edges"`
+	// Pipelines sorted by name.
+	Pipelines []TopologyPipeline `json:"pipelines"`
+}
+
+// TopologyNode describes a node of the pipeline graph.
+type TopologyNode struct {
+	// ID is unique within the Topology.
+	ID string `json:"id"`
+	// Kind is one of receiver, processor, exporter, connector, capabilities or fanout.
+	Kind string `json:"kind"`
+	// ComponentID is empty for nodes that are not backed by a configured component.
+	ComponentID string `json:"component_id,omitempty"`
+	// Pipeline is set for nodes that belong to a single pipeline.
+	Pipeline string `json:"pipeline,omitempty"`
+	// Signal is the signal received by the node. For connectors it is the signal of
+	// the pipelines it exports from.
+	Signal string `json:"signal"`
+	// OutputSignal is the signal of the pipelines a connector receives into.
+	OutputSignal string `json:"output_signal,omitempty"`
+}
+
+// TopologyEdge describes the flow of data between two nodes.
+type TopologyEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// TopologyPipeline lists the IDs of the nodes owned by a pipeline, in data flow order.
+// Receivers, exporters and connectors can be shared by pipelines and are not listed.
+type TopologyPipeline struct {
+	Name  string   `json:"name"`
+	Nodes []string `json:"nodes"`
+}
+
+// BuildTopology builds the topology of the pipelines described by set, validating
+// how connectors are used, without instantiating any component.
+// Only set.PipelineConfigs and set.ConnectorBuilder are used.
+func BuildTopology(set Settings) (*Topology, error) {
+	g := newGraph(set)
+	if err := g.createNodes(set); err != nil {
+		return nil, err
+	}
+	g.createEdges()
+	return g.Topology(), nil
+}
+
+// Topology returns the topology of the graph.
+func (g *Graph) Topology() *Topology {
+	t := &Topology{}
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		t.Nodes = append(t.Nodes, topologyNodeOf(nodes.Node()))
+		to := g.componentGraph.From(nodes.Node().ID())
+		for to.Next() {
+			t.Edges = append(t.Edges, TopologyEdge{
+				From: topologyNodeID(nodes.Node()),
+				To:   topologyNodeID(to.Node()),
+			})
+		}
+	}
+	sort.Slice(t.Nodes, func(i, j int) bool {
+		return t.Nodes[i].ID < t.Nodes[j].ID
+	})
+	sort.Slice(t.Edges, func(i, j int) bool {
+		if t.Edges[i].From != t.Edges[j].From {
+			return t.Edges[i].From < t.Edges[j].From
+		}
+		return t.Edges[i].To < t.Edges[j].To
+	})
+
+	for pipelineID, pn := range g.pipelines {
+		tp := TopologyPipeline{Name: pipelineID.String()}
+		tp.Nodes = append(tp.Nodes, topologyNodeID(pn.capabilitiesNode))
+		for _, proc := range pn.processors {
+			tp.Nodes = append(tp.Nodes, topologyNodeID(proc))
+		}
+		tp.Nodes = append(tp.Nodes, topologyNodeID(pn.fanOutNode))
+		t.Pipelines = append(t.Pipelines, tp)
+	}
+	sort.Slice(t.Pipelines, func(i, j int) bool {
+		return t.Pipelines[i].Name < t.Pipelines[j].Name
+	})
+	return t
+}
+
+// topologyNodeID returns a readable ID for n, unique within the graph.
+func topologyNodeID(n graph.Node) string {
+	switch n := n.(type) {
+	case *receiverNode:
+		return "receiver/" + n.pipelineType.String() + "/" + n.componentID.String()
+	case *processorNode:
+		return "processor/" + n.pipelineID.String() + "/" + n.componentID.String()
+	case *exporterNode:
+		return "exporter/" + n.pipelineType.String() + "/" + n.componentID.String()
+	case *connectorNode:
+		return "connector/" + n.exprPipelineType.String() + "/" + n.rcvrPipelineType.String() + "/" + n.componentID.String()
+	case *capabilitiesNode:
+		return capabilitiesSeed + "/" + n.pipelineID.String()
+	case *fanOutNode:
+		return "fanout/" + n.pipelineID.String()
+	}
+	return strconv.FormatInt(n.ID(), 10)
+}
+
+func topologyNodeOf(n graph.Node) TopologyNode {
+	tn := TopologyNode{ID: topologyNodeID(n)}
+	switch n := n.(type) {
+	case *receiverNode:
+		tn.Kind, tn.ComponentID, tn.Signal = "receiver", n.componentID.String(), n.pipelineType.String()
+	case *processorNode:
+		tn.Kind, tn.ComponentID = "processor", n.componentID.String()
+		tn.Pipeline, tn.Signal = n.pipelineID.String(), n.pipelineID.Signal().String()
+	case *exporterNode:
+		tn.Kind, tn.ComponentID, tn.Signal = "exporter", n.componentID.String(), n.pipelineType.String()
+	case *connectorNode:
+		tn.Kind, tn.ComponentID = "connector", n.componentID.String()
+		tn.Signal, tn.OutputSignal = n.exprPipelineType.String(), n.rcvrPipelineType.String()
+	case *capabilitiesNode:
+		tn.Kind, tn.Pipeline, tn.Signal = "capabilities", n.pipelineID.String(), n.pipelineID.Signal().String()
+	case *fanOutNode:
+		tn.Kind, tn.Pipeline, tn.Signal = "fanout", n.pipelineID.String(), n.pipelineID.Signal().String()
+	}
+	return tn
+}
+
+// label returns the text displayed for the node in diagrams.
+func (n TopologyNode) label() string {
+	switch n.Kind {
+	case "capabilities", "fanout":
+		return n.Kind
+	case "connector":
+		return fmt.Sprintf("connector: %s (%s -> %s)", n.ComponentID, n.Signal, n.OutputSignal)
+	}
+	return n.Kind + ": " + n.ComponentID
+}
+
+// displayName returns the label of the node along with the pipeline or signal it belongs to.
+func (n TopologyNode) displayName() string {
+	switch {
+	case n.Kind == "connector":
+		return n.label()
+	case n.Pipeline != "":
+		return n.label() + " [" + n.Pipeline + "]"
+	}
+	return n.label() + " [" + n.Signal + "]"
+}
+
+// Write renders the topology in the given format.
+func (t *Topology) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatDOT:
+		return t.writeDOT(w)
+	case FormatMermaid:
+		return t.writeMermaid(w)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(t)
+	}
+	return unsupportedFormatError(string(format))
+}
+
+func (t *Topology) writeDOT(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("digraph pipelines {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	owned := t.ownedNodes()
+	for i, p := range t.Pipelines {
+		fmt.Fprintf(&sb, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&sb, "    label=%s;\n", dotQuote(p.Name))
+		for _, id := range p.Nodes {
+			fmt.Fprintf(&sb, "    %s [label=%s];\n", dotQuote(id), dotQuote(t.node(id).label()))
+		}
+		sb.WriteString("  }\n")
+	}
+	for _, n := range t.Nodes {
+		if owned[n.ID] {
+			continue
+		}
+		fmt.Fprintf(&sb, "  %s [label=%s];\n", dotQuote(n.ID), dotQuote(n.label()))
+	}
+	for _, e := range t.Edges {
+		fmt.Fprintf(&sb, "  %s -> %s;\n", dotQuote(e.From), dotQuote(e.To))
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (t *Topology) writeMermaid(w io.Writer) error {
+	// Mermaid node IDs cannot contain most punctuation, so nodes are referenced by index.
+	ids := make(map[string]string, len(t.Nodes))
+	for i, n := range t.Nodes {
+		ids[n.ID] = "n" + strconv.Itoa(i)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	owned := t.ownedNodes()
+	for i, p := range t.Pipelines {
+		fmt.Fprintf(&sb, "  subgraph p%d [%s]\n", i, mermaidQuote(p.Name))
+		for _, id := range p.Nodes {
+			fmt.Fprintf(&sb, "    %s[%s]\n", ids[id], mermaidQuote(t.node(id).label()))
+		}
+		sb.WriteString("  end\n")
+	}
+	for _, n := range t.Nodes {
+		if owned[n.ID] {
+			continue
+		}
+		fmt.Fprintf(&sb, "  %s[%s]\n", ids[n.ID], mermaidQuote(n.label()))
+	}
+	for _, e := range t.Edges {
+		fmt.Fprintf(&sb, "  %s --> %s\n", ids[e.From], ids[e.To])
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// ownedNodes returns the IDs of the nodes listed by a pipeline.
+func (t *Topology) ownedNodes() map[string]bool {
+	owned := make(map[string]bool)
+	for _, p := range t.Pipelines {
+		for _, id := range p.Nodes {
+			owned[id] = true
+		}
+	}
+	return owned
+}
+
+func (t *Topology) node(id string) TopologyNode {
+	i := sort.Search(len(t.Nodes), func(i int) bool { return t.Nodes[i].ID >= id })
+	if i < len(t.Nodes) && t.Nodes[i].ID == id {
+		return t.Nodes[i]
+	}
+	return TopologyNode{ID: id}
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
+
+// ParseFormat returns the Format with the given name.
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+	return "", unsupportedFormatError(name)
+}
+
+func unsupportedFormatError(name string) error {
+	return fmt.Errorf("unsupported topology format %q, must be one of %v", name, Formats)
+}
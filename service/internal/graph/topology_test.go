@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func newTopologyTestSettings() Settings {
+	return Settings{
+		ConnectorBuilder: builders.NewConnector(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleconnector"): testcomponents.ExampleConnectorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]connector.Factory{
+				testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory,
+			},
+		),
+		PipelineConfigs: pipelines.Config{
+			pipeline.NewID(pipeline.SignalTraces): {
+				Receivers:  []component.ID{component.MustNewID("examplereceiver")},
+				Processors: []component.ID{component.MustNewID("exampleprocessor")},
+				Exporters:  []component.ID{component.MustNewID("exampleexporter"), component.MustNewID("exampleconnector")},
+			},
+			pipeline.NewID(pipeline.SignalMetrics): {
+				Receivers: []component.ID{component.MustNewID("exampleconnector")},
+				Exporters: []component.ID{component.MustNewID("exampleexporter")},
+			},
+		},
+	}
+}
+
+func TestBuildTopology(t *testing.T) {
+	topology, err := BuildTopology(newTopologyTestSettings())
+	require.NoError(t, err)
+
+	assert.Equal(t, []TopologyNode{
+		{ID: "capabilities/metrics", Kind: "capabilities", Pipeline: "metrics", Signal: "metrics"},
+		{ID: "capabilities/traces", Kind: "capabilities", Pipeline: "traces", Signal: "traces"},
+		{ID: "connector/traces/metrics/exampleconnector", Kind: "connector", ComponentID: "exampleconnector", Signal: "traces", OutputSignal: "metrics"},
+		{ID: "exporter/metrics/exampleexporter", Kind: "exporter", ComponentID: "exampleexporter", Signal: "metrics"},
+		{ID: "exporter/traces/exampleexporter", Kind: "exporter", ComponentID: "exampleexporter", Signal: "traces"},
+		{ID: "fanout/metrics", Kind: "fanout", Pipeline: "metrics", Signal: "metrics"},
+		{ID: "fanout/traces", Kind: "fanout", Pipeline: "traces", Signal: "traces"},
+		{ID: "processor/traces/exampleprocessor", Kind: "processor", ComponentID: "exampleprocessor", Pipeline: "traces", Signal: "traces"},
+		{ID: "receiver/traces/examplereceiver", Kind: "receiver", ComponentID: "examplereceiver", Signal: "traces"},
+	}, topology.Nodes)
+	assert.Equal(t, []TopologyEdge{
+		{From: "capabilities/metrics", To: "fanout/metrics"},
+		{From: "capabilities/traces", To: "processor/traces/exampleprocessor"},
+		{From: "connector/traces/metrics/exampleconnector", To: "capabilities/metrics"},
+		{From: "fanout/metrics", To: "exporter/metrics/exampleexporter"},
+		{From: "fanout/traces", To: "connector/traces/metrics/exampleconnector"},
+		{From: "fanout/traces", To: "exporter/traces/exampleexporter"},
+		{From: "processor/traces/exampleprocessor", To: "fanout/traces"},
+		{From: "receiver/traces/examplereceiver", To: "capabilities/traces"},
+	}, topology.Edges)
+	assert.Equal(t, []TopologyPipeline{
+		{Name: "metrics", Nodes: []string{"capabilities/metrics", "fanout/metrics"}},
+		{Name: "traces", Nodes: []string{"capabilities/traces", "processor/traces/exampleprocessor", "fanout/traces"}},
+	}, topology.Pipelines)
+}
+
+func TestBuildTopologyError(t *testing.T) {
+	set := newTopologyTestSettings()
+	delete(set.PipelineConfigs, pipeline.NewID(pipeline.SignalMetrics))
+	_, err := BuildTopology(set)
+	require.EqualError(t, err, `connector "exampleconnector" used as exporter in traces pipeline but not used in any supported receiver pipeline`)
+}
+
+func TestTopologyWrite(t *testing.T) {
+	topology, err := BuildTopology(newTopologyTestSettings())
+	require.NoError(t, err)
+
+	var dot bytes.Buffer
+	require.NoError(t, topology.Write(&dot, FormatDOT))
+	assert.Equal(t, `digraph pipelines {
+  rankdir=LR;
+  subgraph cluster_0 {
+    label="metrics";
+    "capabilities/metrics" [label="capabilities"];
+    "fanout/metrics" [label="fanout"];
+  }
+  subgraph cluster_1 {
+    label="traces";
+    "capabilities/traces" [label="capabilities"];
+    "processor/traces/exampleprocessor" [label="processor: exampleprocessor"];
+    "fanout/traces" [label="fanout"];
+  }
+  "connector/traces/metrics/exampleconnector" [label="connector: exampleconnector (traces -> metrics)"];
+  "exporter/metrics/exampleexporter" [label="exporter: exampleexporter"];
+  "exporter/traces/exampleexporter" [label="exporter: exampleexporter"];
+  "receiver/traces/examplereceiver" [label="receiver: examplereceiver"];
+  "capabilities/metrics" -> "fanout/metrics";
+  "capabilities/traces" -> "processor/traces/exampleprocessor";
+  "connector/traces/metrics/exampleconnector" -> "capabilities/metrics";
+  "fanout/metrics" -> "exporter/metrics/exampleexporter";
+  "fanout/traces" -> "connector/traces/metrics/exampleconnector";
+  "fanout/traces" -> "exporter/traces/exampleexporter";
+  "processor/traces/exampleprocessor" -> "fanout/traces";
+  "receiver/traces/examplereceiver" -> "capabilities/traces";
+}
+`, dot.String())
+
+	var mermaid bytes.Buffer
+	require.NoError(t, topology.Write(&mermaid, FormatMermaid))
+	assert.Equal(t, `flowchart LR
+  subgraph p0 ["metrics"]
+    n0["capabilities"]
+    n5["fanout"]
+  end
+  subgraph p1 ["traces"]
+    n1["capabilities"]
+    n7["processor: exampleprocessor"]
+    n6["fanout"]
+  end
+  n2["connector: exampleconnector (traces -> metrics)"]
+  n3["exporter: exampleexporter"]
+  n4["exporter: exampleexporter"]
+  n8["receiver: examplereceiver"]
+  n0 --> n5
+  n1 --> n7
+  n2 --> n0
+  n5 --> n3
+  n6 --> n2
+  n6 --> n4
+  n7 --> n6
+  n8 --> n1
+`, mermaid.String())
+
+	var out bytes.Buffer
+	require.NoError(t, topology.Write(&out, FormatJSON))
+	var decoded Topology
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, *topology, decoded)
+
+	require.EqualError(t, topology.Write(&out, "svg"), `unsupported topology format "svg", must be one of [dot mermaid json]`)
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range Formats {
+		got, err := ParseFormat(string(f))
+		require.NoError(t, err)
+		assert.Equal(t, f, got)
+	}
+	_, err := ParseFormat("svg")
+	require.Error(t, err)
+}
+
+func TestHandleTopologyZPages(t *testing.T) {
+	g := newGraph(newTopologyTestSettings())
+	require.NoError(t, g.createNodes(newTopologyTestSettings()))
+	g.createEdges()
+
+	tests := []struct {
+		query       string
+		status      int
+		contentType string
+		contains    string
+	}{
+		{query: "", status: http.StatusOK, contentType: "text/html; charset=utf-8", contains: "flowchart LR"},
+		{query: "?format=dot", status: http.StatusOK, contentType: "text/plain; charset=utf-8", contains: "digraph pipelines"},
+		{query: "?format=mermaid", status: http.StatusOK, contentType: "text/plain; charset=utf-8", contains: "flowchart LR"},
+		{query: "?format=json", status: http.StatusOK, contentType: "application/json", contains: `"nodes"`},
+		{query: "?format=svg", status: http.StatusBadRequest, contentType: "text/plain; charset=utf-8", contains: "unsupported topology format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			g.HandleTopologyZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/topologyz"+tt.query, nil))
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.contentType, rec.Header().Get("Content-Type"))
+			assert.Contains(t, rec.Body.String(), tt.contains)
+		})
+	}
+}
@@ -6,6 +6,8 @@ package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 import (
 	"net/http"
 	"sort"
+	"strconv"
+	"strings"
 
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
@@ -16,6 +18,7 @@ const (
 	zPipelineName  = "pipelinenamez"
 	zComponentName = "componentnamez"
 	zComponentKind = "componentkindz"
+	zFormat        = "format"
 )
 
 func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
@@ -79,3 +82,77 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	}
 	zpages.WriteHTMLPageFooter(w)
 }
+
+// HandleTopologyZPages renders the topology of the pipelines. The "format" URL param
+// selects the raw DOT, Mermaid or JSON output, otherwise an HTML page embedding the
+// Mermaid output is returned.
+func (g *Graph) HandleTopologyZPages(w http.ResponseWriter, r *http.Request) {
+	topology := g.Topology()
+	if name := r.URL.Query().Get(zFormat); name != "" {
+		format, err := ParseFormat(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		contentType := "text/plain; charset=utf-8"
+		if format == FormatJSON {
+			contentType = "application/json"
+		}
+		w.Header().Set("Content-Type", contentType)
+		_ = topology.Write(w, format)
+		return
+	}
+
+	var sb strings.Builder
+	_ = topology.Write(&sb, FormatMermaid)
+	data := zpages.TopologyData{Content: sb.String()}
+	for _, f := range Formats {
+		data.Links = append(data.Links, zpages.TopologyLinkData{
+			Name: string(f),
+			URL:  r.URL.Path + "?" + zFormat + "=" + string(f),
+		})
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Pipelines Topology"})
+	zpages.WriteHTMLTopology(w, data)
+	zpages.WriteHTMLPageFooter(w)
+}
+
+// HandleThroughputZPages renders the number of items and errors that went through each
+// edge of the graph, as counted by the obsreport metrics of the components.
+func (g *Graph) HandleThroughputZPages(w http.ResponseWriter, r *http.Request) {
+	edges, err := g.Throughput(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Pipelines Throughput"})
+	if edges == nil {
+		zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+			Name: "Throughput is only available when service::telemetry::metrics::level is basic or higher.",
+		})
+		zpages.WriteHTMLPageFooter(w)
+		return
+	}
+
+	data := zpages.ThroughputTableData{Rows: make([]zpages.ThroughputTableRowData, 0, len(edges))}
+	for _, e := range edges {
+		row := zpages.ThroughputTableRowData{
+			From:   e.From.displayName(),
+			To:     e.To.displayName(),
+			Items:  "-",
+			Errors: "-",
+		}
+		if e.Known {
+			row.Items = strconv.FormatInt(e.Items, 10)
+			row.Errors = strconv.FormatInt(e.Errors, 10)
+			row.HasErrors = e.Errors > 0
+		}
+		data.Rows = append(data.Rows, row)
+	}
+	zpages.WriteHTMLThroughputTable(w, data)
+	zpages.WriteHTMLPageFooter(w)
+}
@@ -44,6 +44,14 @@ var (
 	//go:embed templates/features_table.html
 	featuresTableBytes    []byte
 	featuresTableTemplate = parseTemplate("features_table", featuresTableBytes)
+
+	//go:embed templates/topology.html
+	topologyBytes    []byte
+	topologyTemplate = parseTemplate("topology", topologyBytes)
+
+	//go:embed templates/throughput_table.html
+	throughputTableBytes    []byte
+	throughputTableTemplate = parseTemplate("throughput_table", throughputTableBytes)
 )
 
 func parseTemplate(name string, bytes []byte) *template.Template {
@@ -153,6 +161,8 @@ func getValue(row [2]string) string {
 // FeatureGateTableData contains data for feature gate table template.
 type FeatureGateTableData struct {
 	Rows []FeatureGateTableRowData
+	// Token is sent with the forms enabling and disabling the gates.
+	Token string
 }
 
 // FeatureGateTableRowData contains data for one row in feature gate table template.
@@ -164,6 +174,8 @@ type FeatureGateTableRowData struct {
 	FromVersion  string
 	ToVersion    string
 	ReferenceURL string
+	// Toggle is true if the gate can be enabled or disabled from the page.
+	Toggle bool
 }
 
 // WriteHTMLFeaturesTable writes a table summarizing registered feature gates.
@@ -172,3 +184,45 @@ func WriteHTMLFeaturesTable(w io.Writer, ftd FeatureGateTableData) {
 		log.Printf("zpages: executing template: %v", err)
 	}
 }
+
+// TopologyData contains data for the topology template.
+type TopologyData struct {
+	// Links to the topology rendered in other formats.
+	Links []TopologyLinkData
+	// Content is the rendered topology, displayed as preformatted text.
+	Content string
+}
+
+// TopologyLinkData contains data for one link in the topology template.
+type TopologyLinkData struct {
+	Name string
+	URL  string
+}
+
+// WriteHTMLTopology writes the rendered topology of the pipelines.
+func WriteHTMLTopology(w io.Writer, td TopologyData) {
+	if err := topologyTemplate.Execute(w, td); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
+// ThroughputTableData contains data for the throughput table template.
+type ThroughputTableData struct {
+	Rows []ThroughputTableRowData
+}
+
+// ThroughputTableRowData contains data for one edge in the throughput table template.
+type ThroughputTableRowData struct {
+	From      string
+	To        string
+	Items     string
+	Errors    string
+	HasErrors bool
+}
+
+// WriteHTMLThroughputTable writes a table with the items that went through each edge of the pipelines.
+func WriteHTMLThroughputTable(w io.Writer, ttd ThroughputTableData) {
+	if err := throughputTableTemplate.Execute(w, ttd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
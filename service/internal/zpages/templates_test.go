@@ -77,6 +77,22 @@ func TestNoCrash(t *testing.T) {
 				Enabled:     false,
 				Description: "test gate",
 			},
+			{
+				ID:      "toggle",
+				Enabled: true,
+				Toggle:  true,
+			},
+		}})
+	})
+	assert.NotPanics(t, func() {
+		WriteHTMLTopology(buf, TopologyData{
+			Links:   []TopologyLinkData{{Name: "dot", URL: "topologyz?format=dot"}},
+			Content: "flowchart LR",
+		})
+	})
+	assert.NotPanics(t, func() {
+		WriteHTMLThroughputTable(buf, ThroughputTableData{Rows: []ThroughputTableRowData{
+			{From: "receiver: otlp", To: "capabilities", Items: "10", Errors: "1", HasErrors: true},
 		}})
 	})
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
@@ -5,6 +5,7 @@ package service
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -210,6 +211,36 @@ func TestServiceGetFactory(t *testing.T) {
 	assert.Nil(t, srv.host.GetFactory(42, nopType))
 }
 
+func TestWriteTopology(t *testing.T) {
+	cfg := newNopConfigPipelineConfigs(pipelines.Config{
+		pipeline.NewID(pipeline.SignalTraces): {
+			Receivers: []component.ID{component.NewID(nopType)},
+			Exporters: []component.ID{component.NewID(nopType)},
+		},
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTopology(&buf, "mermaid", newNopSettings(), cfg))
+	assert.Equal(t, `flowchart LR
+  subgraph p0 ["traces"]
+    n0["capabilities"]
+    n2["fanout"]
+  end
+  n1["exporter: nop"]
+  n3["receiver: nop"]
+  n0 --> n2
+  n2 --> n1
+  n3 --> n0
+`, buf.String())
+
+	require.EqualError(t, WriteTopology(&buf, "png", newNopSettings(), cfg), `unsupported topology format "png", must be one of [dot mermaid json]`)
+
+	// The returned formats are a copy.
+	formats := TopologyFormats()
+	formats[0] = "png"
+	assert.Equal(t, []string{"dot", "mermaid", "json"}, TopologyFormats())
+}
+
 func TestServiceGetExtensions(t *testing.T) {
 	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
 	require.NoError(t, err)
@@ -602,6 +633,9 @@ func assertZPages(t *testing.T, zpagesAddr string) {
 		"/debug/pipelinez",
 		"/debug/servicez",
 		"/debug/extensionz",
+		"/debug/topologyz",
+		"/debug/topologyz?format=json",
+		"/debug/throughputz",
 	}
 
 	testZPagePathFn := func(t *testing.T, path string) {
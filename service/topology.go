@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"io"
+
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/graph"
+)
+
+// TopologyFormats returns the formats supported by WriteTopology.
+func TopologyFormats() []string {
+	return []string{string(graph.FormatDOT), string(graph.FormatMermaid), string(graph.FormatJSON)}
+}
+
+// WriteTopology renders the graph of receivers, processors, connectors and exporters
+// described by the pipelines of cfg in the given format, one of TopologyFormats().
+// Components are not instantiated, only the connectors configuration and factories of set are used.
+// The output is meant for humans and tooling reviewing the pipelines, and is not stable.
+func WriteTopology(w io.Writer, format string, set Settings, cfg Config) error {
+	f, err := graph.ParseFormat(format)
+	if err != nil {
+		return err
+	}
+	topology, err := graph.BuildTopology(graph.Settings{
+		ConnectorBuilder: builders.NewConnector(set.ConnectorsConfigs, set.ConnectorsFactories),
+		PipelineConfigs:  cfg.Pipelines,
+	})
+	if err != nil {
+		return err
+	}
+	return topology.Write(w, f)
+}